@@ -0,0 +1,254 @@
+package ssmconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// defaultCacheBackendTTL is how long parameters written to a CacheBackend stay valid.
+const defaultCacheBackendTTL = 5 * time.Minute
+
+// cacheKeyPrefix namespaces the keys the Loader writes to a CacheBackend.
+const cacheKeyPrefix = "ssmconfig:"
+
+// CacheBackend stores loaded SSM parameters outside the Loader, e.g. in Redis or memcached,
+// so several processes can share one load instead of each calling SSM.
+// Implementations must be safe for concurrent use.
+//
+// Values are stored decrypted. A prefix holding SecureString parameters is only written if the
+// loader also uses WithCacheBackendEncryption. Keys are SSM paths, namespaced only by
+// WithCacheBackendNamespace, so loaders for different accounts or regions must not share a
+// backend without distinct namespaces.
+type CacheBackend interface {
+	// Get returns the value stored under key. found is false if the key is missing or expired.
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+	// Set stores value under key for ttl, replacing any previous value. A ttl of 0 means the
+	// value doesn't expire.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// cipherFunc encrypts or decrypts a SecureString value stored in a CacheBackend.
+type cipherFunc func(value []byte) ([]byte, error)
+
+// cachedParameters is the document stored in a CacheBackend for one SSM path.
+type cachedParameters struct {
+	Values    map[string]string              `json:"values"`              // full parameter name -> value
+	Encrypted map[string][]byte              `json:"encrypted,omitempty"` // SecureString values, encrypted
+	Types     map[string]types.ParameterType `json:"types"`               // full parameter name -> SSM type
+	Newest    time.Time                      `json:"newest"`              // latest LastModifiedDate
+}
+
+// MemoryCache is an in-process CacheBackend. A Loader without a backend already caches
+// in memory; sharing one MemoryCache lets several Loaders (e.g. copies made with With)
+// share loads, and it serves as a reference for out-of-process implementations.
+type MemoryCache struct {
+	entries sync.Map // key -> *memoryCacheEntry
+	clock   clock
+}
+
+type memoryCacheEntry struct {
+	value   []byte
+	expires time.Time // Zero if the entry doesn't expire
+}
+
+// NewMemoryCache creates an empty in-process CacheBackend.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{clock: realClock{}}
+}
+
+// Get returns the value stored under key, or found=false if it is missing or expired.
+func (c *MemoryCache) Get(_ context.Context, key string) (value []byte, found bool, err error) {
+	stored, ok := c.entries.Load(key)
+	if !ok {
+		return nil, false, nil
+	}
+	entry, ok := stored.(*memoryCacheEntry)
+	if !ok {
+		return nil, false, fmt.Errorf("invalid cache entry type")
+	}
+	if !entry.expires.IsZero() && !c.clock.Now().Before(entry.expires) {
+		c.entries.CompareAndDelete(key, stored)
+		return nil, false, nil
+	}
+	return append([]byte(nil), entry.value...), true, nil
+}
+
+// Set stores value under key for ttl. A ttl of 0 means the value doesn't expire.
+func (c *MemoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	entry := &memoryCacheEntry{value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		entry.expires = c.clock.Now().Add(ttl)
+	}
+	c.entries.Store(key, entry)
+	return nil
+}
+
+// Delete removes key. The Loader never deletes entries; this is for callers managing the cache.
+func (c *MemoryCache) Delete(_ context.Context, key string) error {
+	c.entries.Delete(key)
+	return nil
+}
+
+// cacheBackendKey returns the CacheBackend key for an SSM path (e.g. "ssmconfig:/myapp"),
+// including the namespace if one is set (e.g. "ssmconfig:prod/eu-west-1:/myapp").
+func (l *Loader) cacheBackendKey(path string) string {
+	if l.cacheNamespace != "" {
+		return cacheKeyPrefix + l.cacheNamespace + ":" + path
+	}
+	return cacheKeyPrefix + path
+}
+
+// fetchParametersCached returns the parameters under an SSM path, reading through the cache
+// backend if one is configured. Backend failures are logged and fall back to SSM, so a cache
+// outage never fails a load.
+func (l *Loader) fetchParametersCached(ctx context.Context, path string) (map[string]string, error) {
+	// Recordings and snapshots are already local, so they bypass the backend
+	if l.cacheBackend == nil || l.replayFile != "" || l.snapshotFile != "" {
+		return l.fetchParameters(ctx, path)
+	}
+
+	key := l.cacheBackendKey(path)
+	// After an invalidation the backend isn't read, so the fetch below overwrites the shared entry
+	_, refresh := l.backendRefresh.LoadAndDelete(path)
+	if !refresh {
+		if params, found := l.readCacheBackend(ctx, path, key); found {
+			return params, nil
+		}
+	}
+
+	params, err := l.fetchParameters(ctx, path)
+	if err != nil {
+		if refresh {
+			l.backendRefresh.Store(path, struct{}{})
+		}
+		// Partial parameters are returned but never written to the backend
+		return params, err
+	}
+	l.writeCacheBackend(ctx, path, key, params)
+	return params, nil
+}
+
+// readCacheBackend returns the parameters stored in the backend for an SSM path.
+// found is false on a miss, and on a failure, which is logged.
+func (l *Loader) readCacheBackend(ctx context.Context, path, key string) (map[string]string, bool) {
+	data, found, err := l.cacheBackend.Get(ctx, key)
+	if err != nil {
+		l.logCacheError("reading", key, err)
+		return nil, false
+	}
+	if !found {
+		return nil, false
+	}
+
+	var cached cachedParameters
+	if err := json.Unmarshal(data, &cached); err != nil {
+		l.logCacheError("decoding", key, err)
+		return nil, false
+	}
+	if cached.Values == nil {
+		cached.Values = make(map[string]string)
+	}
+	if len(cached.Encrypted) > 0 {
+		if l.cacheDecrypt == nil {
+			l.logCacheError("decrypting", key, fmt.Errorf("entry holds encrypted values (see WithCacheBackendEncryption)"))
+			return nil, false
+		}
+		for name, encrypted := range cached.Encrypted {
+			value, err := l.cacheDecrypt(encrypted)
+			if err != nil {
+				l.logCacheError("decrypting", key, fmt.Errorf("%s: %w", name, err))
+				return nil, false
+			}
+			cached.Values[name] = string(value)
+		}
+	}
+
+	for name, typ := range cached.Types {
+		l.parameterTypes.Store(name, typ)
+	}
+	l.stamps.Store(path, parameterStamp{newest: cached.Newest, count: len(cached.Values)})
+	return cached.Values, true
+}
+
+// writeCacheBackend stores the parameters fetched for an SSM path in the backend, encrypting
+// SecureString values if WithCacheBackendEncryption is set. Without it, a path holding
+// SecureString values isn't written at all. Failures are logged.
+func (l *Loader) writeCacheBackend(ctx context.Context, path, key string, params map[string]string) {
+	cached := cachedParameters{
+		Values: make(map[string]string, len(params)),
+		Types:  make(map[string]types.ParameterType),
+	}
+	if value, ok := l.stamps.Load(path); ok {
+		if stamp, isStamp := value.(parameterStamp); isStamp {
+			cached.Newest = stamp.newest
+		}
+	}
+	for name, value := range params {
+		if typ, ok := l.parameterTypes.Load(name); ok {
+			if paramType, isType := typ.(types.ParameterType); isType {
+				cached.Types[name] = paramType
+			}
+		}
+		if cached.Types[name] != types.ParameterTypeSecureString {
+			cached.Values[name] = value
+			continue
+		}
+		if l.cacheEncrypt == nil {
+			// Secrets never reach the backend in plaintext; each process loads this path from SSM
+			return
+		}
+		encrypted, err := l.cacheEncrypt([]byte(value))
+		if err != nil {
+			// Nothing is written rather than falling back to storing the secret in plaintext
+			l.logCacheError("encrypting", key, fmt.Errorf("%s: %w", name, err))
+			return
+		}
+		if cached.Encrypted == nil {
+			cached.Encrypted = make(map[string][]byte)
+		}
+		cached.Encrypted[name] = encrypted
+	}
+
+	// Empty results are shared only as long as negative caching remembers them
+	ttl := l.cacheBackendTTL
+	if len(params) == 0 && l.negativeTTL > 0 {
+		ttl = l.negativeTTL
+	}
+	data, err := json.Marshal(cached)
+	if err == nil {
+		err = l.cacheBackend.Set(ctx, key, data, ttl)
+	}
+	if err != nil {
+		l.logCacheError("writing", key, err)
+	}
+}
+
+// refreshCacheBackend makes the next fetch of a prefix skip the cache backend, so it calls SSM
+// and overwrites the shared entry. Deleting the entry instead would make every process sharing
+// it call SSM.
+func (l *Loader) refreshCacheBackend(prefix string) {
+	if l.cacheBackend == nil {
+		return
+	}
+	l.backendRefresh.Store(l.ssmPath(prefix), struct{}{})
+}
+
+// now returns the current time from the loader's clock.
+func (l *Loader) now() time.Time {
+	if l.clock == nil {
+		return time.Now()
+	}
+	return l.clock.Now()
+}
+
+// logCacheError logs a cache backend failure, which is never fatal to a load.
+func (l *Loader) logCacheError(action, key string, err error) {
+	if l.logger != nil {
+		l.logger("WARNING: Cache backend failed %s %s: %v", action, key, err)
+	}
+}
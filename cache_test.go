@@ -0,0 +1,377 @@
+package ssmconfig
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingCacheBackend is a CacheBackend whose every call fails.
+type failingCacheBackend struct{}
+
+func (failingCacheBackend) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, errors.New("connection refused")
+}
+
+func (failingCacheBackend) Set(context.Context, string, []byte, time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores and deletes values", func(t *testing.T) {
+		cache := NewMemoryCache()
+
+		_, found, err := cache.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.False(t, found)
+
+		require.NoError(t, cache.Set(ctx, "key", []byte("value"), 0))
+		value, found, err := cache.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.True(t, found)
+		assert.Equal(t, []byte("value"), value)
+
+		require.NoError(t, cache.Delete(ctx, "key"))
+		_, found, err = cache.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("expires values after the TTL", func(t *testing.T) {
+		clock := newFakeClock()
+		cache := &MemoryCache{clock: clock}
+
+		require.NoError(t, cache.Set(ctx, "key", []byte("value"), time.Minute))
+
+		clock.Advance(59 * time.Second)
+		_, found, err := cache.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.True(t, found)
+
+		clock.Advance(time.Second)
+		_, found, err = cache.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+}
+
+func TestWithCacheBackend(t *testing.T) {
+	type Config struct {
+		Host  string   `ssm:"host"`
+		Hosts []string `ssm:"hosts"`
+	}
+
+	newClient := func() *mockSSMClient {
+		client := newMockSSMClient(map[string]string{
+			"/myapp/host":  "db.example.com",
+			"/myapp/hosts": "a,b",
+		})
+		client.parameterTypes = map[string]types.ParameterType{"/myapp/hosts": types.ParameterTypeStringList}
+		return client
+	}
+
+	t.Run("loaders sharing a backend call SSM once", func(t *testing.T) {
+		backend := NewMemoryCache()
+		client1, client2 := newClient(), newClient()
+
+		cfg1, err := LoadWithLoader[Config](newLoader(client1, WithCacheBackend(backend)), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		cfg2, err := LoadWithLoader[Config](newLoader(client2, WithCacheBackend(backend)), context.Background(), "/myapp/")
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, client1.callCount())
+		assert.Equal(t, 0, client2.callCount())
+		assert.Equal(t, cfg1, cfg2)
+		// StringList types survive the round trip through the backend
+		assert.Equal(t, []string{"a", "b"}, cfg2.Hosts)
+	})
+
+	t.Run("writes entries with the configured TTL", func(t *testing.T) {
+		clock := newFakeClock()
+		backend := &MemoryCache{clock: clock}
+		loader := newLoader(newClient(), WithCacheBackend(backend), WithCacheBackendTTL(time.Minute))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+
+		_, found, err := backend.Get(context.Background(), "ssmconfig:/myapp")
+		require.NoError(t, err)
+		assert.True(t, found)
+
+		clock.Advance(time.Minute)
+		_, found, err = backend.Get(context.Background(), "ssmconfig:/myapp")
+		require.NoError(t, err)
+		assert.False(t, found)
+	})
+
+	t.Run("invalidation overwrites the backend entry instead of deleting it", func(t *testing.T) {
+		backend := NewMemoryCache()
+		client := newClient()
+		loader := newLoader(client, WithCacheBackend(backend))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+
+		client.setParameter("/myapp/host", "db2.example.com")
+		loader.InvalidateCache("/myapp/")
+		_, found, err := backend.Get(context.Background(), "ssmconfig:/myapp")
+		require.NoError(t, err)
+		assert.True(t, found, "other processes keep using the entry until it's overwritten")
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "db2.example.com", cfg.Host)
+		assert.Equal(t, 2, client.callCount())
+
+		// Another process gets the refreshed values from the backend
+		other := newClient()
+		cfg, err = LoadWithLoader[Config](newLoader(other, WithCacheBackend(backend)), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "db2.example.com", cfg.Host)
+		assert.Equal(t, 0, other.callCount())
+	})
+
+	t.Run("namespaces keep loaders for different accounts apart", func(t *testing.T) {
+		backend := NewMemoryCache()
+		prod, staging := newClient(), newClient()
+		staging.setParameter("/myapp/host", "staging.example.com")
+
+		_, err := LoadWithLoader[Config](newLoader(prod,
+			WithCacheBackend(backend), WithCacheBackendNamespace("111111111111/eu-west-1")), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		cfg, err := LoadWithLoader[Config](newLoader(staging,
+			WithCacheBackend(backend), WithCacheBackendNamespace("222222222222/eu-west-1")), context.Background(), "/myapp/")
+		require.NoError(t, err)
+
+		assert.Equal(t, "staging.example.com", cfg.Host)
+		assert.Equal(t, 1, staging.callCount())
+		_, found, err := backend.Get(context.Background(), "ssmconfig:111111111111/eu-west-1:/myapp")
+		require.NoError(t, err)
+		assert.True(t, found)
+	})
+
+	t.Run("backend failures fall back to SSM", func(t *testing.T) {
+		var mu sync.Mutex
+		var logged []string
+		client := newClient()
+		loader := newLoader(client,
+			WithCacheBackend(failingCacheBackend{}),
+			WithLogger(func(format string, args ...interface{}) {
+				mu.Lock()
+				defer mu.Unlock()
+				logged = append(logged, fmt.Sprintf(format, args...))
+			}))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "db.example.com", cfg.Host)
+		assert.Equal(t, 1, client.callCount())
+		assert.Equal(t, []string{
+			"WARNING: Cache backend failed reading ssmconfig:/myapp: connection refused",
+			"WARNING: Cache backend failed writing ssmconfig:/myapp: connection refused",
+		}, logged)
+	})
+}
+
+func TestWithNegativeCacheTTL(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("empty prefix is remembered until the TTL passes", func(t *testing.T) {
+		clock := newFakeClock()
+		client := newMockSSMClient(map[string]string{})
+		loader := newLoader(client, WithNegativeCacheTTL(time.Minute))
+		loader.clock = clock
+
+		for i := 0; i < 3; i++ {
+			values, err := loader.loadByPrefix(ctx, "/missing/")
+			require.NoError(t, err)
+			assert.Empty(t, values)
+		}
+		assert.Equal(t, 1, client.callCount())
+
+		// A successful load after the TTL replaces the negative entry
+		client.setParameter("/missing/host", "db.example.com")
+		clock.Advance(time.Minute)
+		values, err := loader.loadByPrefix(ctx, "/missing/")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"host": "db.example.com"}, values)
+
+		clock.Advance(time.Hour)
+		_, err = loader.loadByPrefix(ctx, "/missing/")
+		require.NoError(t, err)
+		assert.Equal(t, 2, client.callCount())
+	})
+
+	t.Run("failed load is remembered until the TTL passes", func(t *testing.T) {
+		clock := newFakeClock()
+		client := newMockSSMClient(map[string]string{"/myapp/host": "db.example.com"})
+		client.err = errors.New("access denied")
+		loader := newLoader(client, WithNegativeCacheTTL(time.Minute))
+		loader.clock = clock
+
+		_, err := loader.loadByPrefix(ctx, "/myapp/")
+		require.Error(t, err)
+
+		client.mu.Lock()
+		client.err = nil
+		client.mu.Unlock()
+
+		_, err = loader.loadByPrefix(ctx, "/myapp/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "access denied")
+		assert.Equal(t, 1, client.callCount())
+
+		clock.Advance(time.Minute)
+		values, err := loader.loadByPrefix(ctx, "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"host": "db.example.com"}, values)
+		assert.Equal(t, 2, client.callCount())
+	})
+
+	t.Run("failures are retried without negative caching", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{})
+		client.err = errors.New("access denied")
+		loader := newLoader(client)
+
+		for i := 0; i < 2; i++ {
+			_, err := loader.loadByPrefix(ctx, "/myapp/")
+			require.Error(t, err)
+		}
+		assert.Equal(t, 2, client.callCount())
+	})
+
+	t.Run("canceled loads are not remembered", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/myapp/host": "db.example.com"})
+		client.delay = time.Second
+		loader := newLoader(client, WithNegativeCacheTTL(time.Minute))
+
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		_, err := loader.loadByPrefix(canceled, "/myapp/")
+		require.ErrorIs(t, err, context.Canceled)
+
+		client.delay = 0
+		values, err := loader.loadByPrefix(ctx, "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"host": "db.example.com"}, values)
+	})
+
+	t.Run("invalidation clears a negative entry", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{})
+		client.err = errors.New("access denied")
+		loader := newLoader(client, WithNegativeCacheTTL(time.Hour))
+
+		_, err := loader.loadByPrefix(ctx, "/myapp/")
+		require.Error(t, err)
+
+		client.mu.Lock()
+		client.err = nil
+		client.mu.Unlock()
+		loader.InvalidateCache("/myapp/")
+
+		_, err = loader.loadByPrefix(ctx, "/myapp/")
+		require.NoError(t, err)
+	})
+}
+
+func TestWithCacheBackendEncryption(t *testing.T) {
+	type Config struct {
+		Host     string `ssm:"host"`
+		Password string `ssm:"password"`
+	}
+
+	newClient := func() *mockSSMClient {
+		client := newMockSSMClient(map[string]string{
+			"/myapp/host":     "db.example.com",
+			"/myapp/password": "hunter2",
+		})
+		client.parameterTypes = map[string]types.ParameterType{"/myapp/password": types.ParameterTypeSecureString}
+		return client
+	}
+	// A reversible stand-in for real encryption
+	encrypt := func(value []byte) ([]byte, error) {
+		return []byte(base64.StdEncoding.EncodeToString(value)), nil
+	}
+	decrypt := func(value []byte) ([]byte, error) {
+		return base64.StdEncoding.DecodeString(string(value))
+	}
+	stored := func(t *testing.T, backend *MemoryCache) string {
+		data, found, err := backend.Get(context.Background(), "ssmconfig:/myapp")
+		require.NoError(t, err)
+		require.True(t, found)
+		return string(data)
+	}
+
+	t.Run("doesn't write SecureString values without encryption", func(t *testing.T) {
+		backend := NewMemoryCache()
+		_, err := LoadWithLoader[Config](newLoader(newClient(), WithCacheBackend(backend)), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		_, found, err := backend.Get(context.Background(), "ssmconfig:/myapp")
+		require.NoError(t, err)
+		assert.False(t, found)
+
+		client := newClient()
+		cfg, err := LoadWithLoader[Config](newLoader(client, WithCacheBackend(backend)), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", cfg.Password)
+		assert.Equal(t, 1, client.callCount())
+	})
+
+	t.Run("encrypts SecureString values only", func(t *testing.T) {
+		backend := NewMemoryCache()
+		newEncryptingLoader := func(client *mockSSMClient) *Loader {
+			return newLoader(client, WithCacheBackend(backend), WithCacheBackendEncryption(encrypt, decrypt))
+		}
+
+		_, err := LoadWithLoader[Config](newEncryptingLoader(newClient()), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		data := stored(t, backend)
+		assert.NotContains(t, data, "hunter2")
+		assert.Contains(t, data, "db.example.com")
+
+		client := newClient()
+		cfg, err := LoadWithLoader[Config](newEncryptingLoader(client), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, Config{Host: "db.example.com", Password: "hunter2"}, *cfg)
+		assert.Equal(t, 0, client.callCount())
+
+		// A loader that can't decrypt the entry loads from SSM
+		client = newClient()
+		cfg, err = LoadWithLoader[Config](newLoader(client, WithCacheBackend(backend)), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", cfg.Password)
+		assert.Equal(t, 1, client.callCount())
+	})
+
+	t.Run("doesn't write the entry when encryption fails", func(t *testing.T) {
+		backend := NewMemoryCache()
+		var logged []string
+		failing := func([]byte) ([]byte, error) { return nil, errors.New("key unavailable") }
+		loader := newLoader(newClient(),
+			WithCacheBackend(backend),
+			WithCacheBackendEncryption(failing, decrypt),
+			WithLogger(func(format string, args ...interface{}) {
+				logged = append(logged, fmt.Sprintf(format, args...))
+			}))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "hunter2", cfg.Password)
+		_, found, err := backend.Get(context.Background(), "ssmconfig:/myapp")
+		require.NoError(t, err)
+		assert.False(t, found)
+		assert.Equal(t, []string{
+			"WARNING: Cache backend failed encrypting ssmconfig:/myapp: /myapp/password: key unavailable",
+		}, logged)
+	})
+}
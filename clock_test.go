@@ -0,0 +1,94 @@
+package ssmconfig
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a clock whose tickers fire only when the test advances time.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*fakeTicker
+}
+
+type fakeTicker struct {
+	clock   *fakeClock
+	c       chan time.Time
+	period  time.Duration
+	next    time.Time
+	stopped bool
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(0, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTicker{clock: c, c: make(chan time.Time, 1), period: d, next: c.now.Add(d)}
+	c.tickers = append(c.tickers, t)
+	return t
+}
+
+// Advance moves time forward and fires every ticker that came due. Like time.Ticker,
+// ticks are dropped when the receiver hasn't consumed the previous one.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.tickers {
+		for !t.stopped && !t.next.After(c.now) {
+			select {
+			case t.c <- t.next:
+			default:
+			}
+			t.next = t.next.Add(t.period)
+		}
+	}
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	t.stopped = true
+}
+
+func TestRefreshingConfig_FakeClock(t *testing.T) {
+	type Config struct {
+		Value string `ssm:"value"`
+	}
+
+	client := newMockSSMClient(map[string]string{"/test/value": "v1"})
+	clock := newFakeClock()
+
+	rc, err := LoadWithAutoRefreshAndLoader[Config](newLoader(client), context.Background(), "/test/",
+		WithRefreshInterval[Config](time.Minute), withClock[Config](clock))
+	require.NoError(t, err)
+	defer rc.Stop()
+
+	client.setParameter("/test/value", "v2")
+
+	// Nothing happens until the interval has elapsed
+	clock.Advance(30 * time.Second)
+	assert.Never(t, func() bool { return rc.Get().Value == "v2" }, 50*time.Millisecond, 5*time.Millisecond)
+
+	clock.Advance(30 * time.Second)
+	assert.Eventually(t, func() bool { return rc.Get().Value == "v2" }, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,103 @@
+package ssmconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// checkRequiredKeys verifies that every key is present with a non-empty value in the merged values.
+// Keys may be relative to the prefixes ("database/host") or absolute paths under one of them
+// ("/myapp/database/host").
+func checkRequiredKeys(values map[string]string, prefixes []string, keys []string, foldCase bool) error {
+	var missing []string
+	for _, key := range keys {
+		relative := key
+		for _, prefix := range prefixes {
+			path := strings.TrimSuffix(normalizePrefix(prefix), "/") + "/"
+			if strings.HasPrefix(key, path) {
+				relative = strings.TrimPrefix(key, path)
+				break
+			}
+		}
+		relative = strings.TrimPrefix(relative, "/")
+		if foldCase {
+			relative = strings.ToLower(relative)
+		}
+		if values[relative] == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required keys: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// checkRequireOneOf verifies that, for each group, at least one of the listed fields is set.
+// Fields are Go field paths (e.g. "Auth.APIKey") resolved against the mapped struct.
+func checkRequireOneOf(dest interface{}, groups [][]string) error {
+	root := reflect.ValueOf(dest).Elem()
+	for _, group := range groups {
+		satisfied := false
+		for _, path := range group {
+			set, err := isFieldSet(root, path)
+			if err != nil {
+				return err
+			}
+			if set {
+				satisfied = true
+				break
+			}
+		}
+		if !satisfied {
+			return fmt.Errorf("at least one of the fields %s must be set", strings.Join(group, ", "))
+		}
+	}
+	return nil
+}
+
+// checkMutuallyExclusive verifies that, for each group, at most one of the listed fields is set.
+func checkMutuallyExclusive(dest interface{}, groups [][]string) error {
+	root := reflect.ValueOf(dest).Elem()
+	for _, group := range groups {
+		var set []string
+		for _, path := range group {
+			ok, err := isFieldSet(root, path)
+			if err != nil {
+				return err
+			}
+			if ok {
+				set = append(set, path)
+			}
+		}
+		if len(set) > 1 {
+			return fmt.Errorf("fields %s are mutually exclusive but %s are all set",
+				strings.Join(group, ", "), strings.Join(set, ", "))
+		}
+	}
+	return nil
+}
+
+// isFieldSet reports whether the field at the dotted path holds a non-zero value.
+// A nil pointer anywhere along the path means the field is not set.
+func isFieldSet(root reflect.Value, path string) (bool, error) {
+	v := root
+	for _, name := range strings.Split(path, ".") {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return false, nil
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			return false, fmt.Errorf("field path %q: %s is not a struct", path, v.Type())
+		}
+		field := v.FieldByName(name)
+		if !field.IsValid() {
+			return false, fmt.Errorf("field path %q: no field %s in %s", path, name, v.Type())
+		}
+		v = field
+	}
+	return !v.IsZero(), nil
+}
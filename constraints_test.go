@@ -0,0 +1,154 @@
+package ssmconfig
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequireOneOf(t *testing.T) {
+	type AuthConfig struct {
+		Token string `ssm:"token"`
+	}
+
+	type Config struct {
+		APIKey string      `ssm:"api_key"`
+		Auth   *AuthConfig `ssm:"auth"`
+		Port   int         `ssm:"port"`
+	}
+
+	t.Run("fails when no field in the group is set", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/myapp/port": "8080"})
+		loader := newLoader(client, WithRequireOneOf("APIKey", "Auth.Token"))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "at least one of the fields APIKey, Auth.Token must be set")
+	})
+
+	t.Run("passes when one field is set", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/myapp/auth/token": "secret"})
+		loader := newLoader(client, WithRequireOneOf("APIKey", "Auth.Token"))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "secret", cfg.Auth.Token)
+	})
+
+	t.Run("checks every group", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/myapp/api_key": "key"})
+		loader := newLoader(client,
+			WithRequireOneOf("APIKey", "Auth.Token"),
+			WithRequireOneOf("Port"))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fields Port must be set")
+	})
+
+	t.Run("reports unknown field paths", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{})
+		loader := newLoader(client, WithRequireOneOf("Missing"))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no field Missing")
+	})
+}
+
+func TestWithMutuallyExclusive(t *testing.T) {
+	type Config struct {
+		StaticEndpoint   string `ssm:"static_endpoint"`
+		ServiceDiscovery bool   `ssm:"service_discovery"`
+		DNSName          string `ssm:"dns_name"`
+	}
+
+	t.Run("fails and reports conflicting fields", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{
+			"/myapp/static_endpoint":   "10.0.0.1:443",
+			"/myapp/service_discovery": "true",
+		})
+		loader := newLoader(client, WithMutuallyExclusive("StaticEndpoint", "ServiceDiscovery", "DNSName"))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.EqualError(t, err, "fields StaticEndpoint, ServiceDiscovery, DNSName are mutually exclusive "+
+			"but StaticEndpoint, ServiceDiscovery are all set")
+	})
+
+	t.Run("passes when at most one field is set", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/myapp/dns_name": "svc.local"})
+		loader := newLoader(client, WithMutuallyExclusive("StaticEndpoint", "ServiceDiscovery", "DNSName"))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "svc.local", cfg.DNSName)
+	})
+
+	t.Run("passes when no field is set", func(t *testing.T) {
+		loader := newLoader(newMockSSMClient(map[string]string{}),
+			WithMutuallyExclusive("StaticEndpoint", "ServiceDiscovery"))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+	})
+}
+
+func TestIsFieldSet(t *testing.T) {
+	type Inner struct {
+		Value int
+	}
+
+	type Outer struct {
+		Name  string
+		Inner *Inner
+	}
+
+	t.Run("nil pointer along the path is not set", func(t *testing.T) {
+		set, err := isFieldSet(reflect.ValueOf(&Outer{}).Elem(), "Inner.Value")
+		require.NoError(t, err)
+		assert.False(t, set)
+	})
+
+	t.Run("non-zero value is set", func(t *testing.T) {
+		set, err := isFieldSet(reflect.ValueOf(&Outer{Inner: &Inner{Value: 1}}).Elem(), "Inner.Value")
+		require.NoError(t, err)
+		assert.True(t, set)
+	})
+
+	t.Run("path through a non-struct is an error", func(t *testing.T) {
+		_, err := isFieldSet(reflect.ValueOf(&Outer{}).Elem(), "Name.Length")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not a struct")
+	})
+}
+
+func TestWithRequiredKeys(t *testing.T) {
+	type Config struct {
+		Port int `ssm:"port"`
+	}
+
+	client := newMockSSMClient(map[string]string{
+		"/myapp/port":          "8080",
+		"/myapp/database/host": "db.internal",
+	})
+
+	t.Run("passes when all keys are present", func(t *testing.T) {
+		loader := newLoader(client, WithRequiredKeys("database/host", "/myapp/port"))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, 8080, cfg.Port)
+	})
+
+	t.Run("lists every missing key", func(t *testing.T) {
+		loader := newLoader(client, WithRequiredKeys("database/host", "/myapp/redis/addr", "queue/url"))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.EqualError(t, err, "missing required keys: /myapp/redis/addr, queue/url")
+	})
+}
@@ -0,0 +1,91 @@
+package ssmconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// DecoderFunc parses a raw parameter value into a value of the registered type.
+type DecoderFunc func(value string) (interface{}, error)
+
+var (
+	decoders   = make(map[reflect.Type]DecoderFunc)
+	decodersMu sync.RWMutex
+)
+
+// RegisterDecoder registers a custom decoder for the type of typeExample.
+// Fields of that type (or pointers to it) are populated by calling the decoder
+// instead of the built-in conversion, e.g.:
+//
+//	ssmconfig.RegisterDecoder(color.RGBA{}, func(s string) (interface{}, error) {
+//	    return parseHexColor(s)
+//	})
+//
+// The decoder must return a value assignable to the registered type.
+func RegisterDecoder(typeExample interface{}, decoder DecoderFunc) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	decoders[reflect.TypeOf(typeExample)] = decoder
+}
+
+// UnregisterDecoder removes the decoder registered for the type of typeExample.
+func UnregisterDecoder(typeExample interface{}) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	delete(decoders, reflect.TypeOf(typeExample))
+}
+
+// GetDecoder retrieves the decoder registered for the given type.
+func GetDecoder(typ reflect.Type) (DecoderFunc, bool) {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+	decoder, ok := decoders[typ]
+	return decoder, ok
+}
+
+// hasDecoder reports whether a decoder is registered for the type or, for pointers, its element type.
+func hasDecoder(typ reflect.Type) bool {
+	if _, ok := GetDecoder(typ); ok {
+		return true
+	}
+	if typ.Kind() == reflect.Ptr {
+		_, ok := GetDecoder(typ.Elem())
+		return ok
+	}
+	return false
+}
+
+// decodeWithRegistry sets the field using a registered decoder.
+// Returns false if no decoder is registered for the field type.
+func decodeWithRegistry(fv reflect.Value, val string) (bool, error) {
+	typ := fv.Type()
+	decoder, ok := GetDecoder(typ)
+	target := fv
+	viaPointer := false
+	if !ok && typ.Kind() == reflect.Ptr {
+		decoder, ok = GetDecoder(typ.Elem())
+		typ = typ.Elem()
+		target = reflect.New(typ).Elem()
+		viaPointer = true
+	}
+	if !ok {
+		return false, nil
+	}
+
+	decoded, err := decoder(val)
+	if err != nil {
+		return true, fmt.Errorf("decoding %v value: %w", typ, err)
+	}
+
+	rv := reflect.ValueOf(decoded)
+	if !rv.IsValid() || !rv.Type().AssignableTo(typ) {
+		return true, fmt.Errorf("decoder for %v returned incompatible type %T", typ, decoded)
+	}
+	target.Set(rv)
+
+	if viaPointer {
+		fv.Set(target.Addr())
+	}
+	return true, nil
+}
@@ -0,0 +1,113 @@
+package ssmconfig
+
+import (
+	"fmt"
+	"image/color"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseHexColor(s string) (interface{}, error) {
+	var c color.RGBA
+	c.A = 0xff
+	if _, err := fmt.Sscanf(s, "#%02x%02x%02x", &c.R, &c.G, &c.B); err != nil {
+		return nil, fmt.Errorf("invalid color %q: %w", s, err)
+	}
+	return c, nil
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	t.Run("registers and retrieves decoder", func(t *testing.T) {
+		RegisterDecoder(color.RGBA{}, parseHexColor)
+		defer UnregisterDecoder(color.RGBA{})
+
+		decoder, ok := GetDecoder(reflect.TypeOf(color.RGBA{}))
+		require.True(t, ok)
+		decoded, err := decoder("#ff0000")
+		require.NoError(t, err)
+		assert.Equal(t, color.RGBA{R: 0xff, A: 0xff}, decoded)
+	})
+
+	t.Run("unregisters decoder", func(t *testing.T) {
+		RegisterDecoder(color.RGBA{}, parseHexColor)
+		UnregisterDecoder(color.RGBA{})
+
+		_, ok := GetDecoder(reflect.TypeOf(color.RGBA{}))
+		assert.False(t, ok)
+	})
+}
+
+func TestMapToStruct_RegisteredDecoders(t *testing.T) {
+	RegisterDecoder(color.RGBA{}, parseHexColor)
+	defer UnregisterDecoder(color.RGBA{})
+
+	t.Run("decodes struct type instead of treating it as nested", func(t *testing.T) {
+		type Config struct {
+			Background color.RGBA `ssm:"background"`
+		}
+
+		values := map[string]string{"background": "#ffffff"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}, result.Background)
+	})
+
+	t.Run("decodes into pointer field", func(t *testing.T) {
+		type Config struct {
+			Foreground *color.RGBA `ssm:"foreground"`
+		}
+
+		values := map[string]string{"foreground": "#000080"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		require.NotNil(t, result.Foreground)
+		assert.Equal(t, color.RGBA{B: 0x80, A: 0xff}, *result.Foreground)
+	})
+
+	t.Run("takes precedence over JSON preference", func(t *testing.T) {
+		type Config struct {
+			Background color.RGBA `ssm:"background"`
+		}
+
+		values := map[string]string{"background": "#00ff00"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, false)
+		require.NoError(t, err)
+		assert.Equal(t, color.RGBA{G: 0xff, A: 0xff}, result.Background)
+	})
+
+	t.Run("returns decoder error", func(t *testing.T) {
+		type Config struct {
+			Background color.RGBA `ssm:"background"`
+		}
+
+		values := map[string]string{"background": "white"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid color")
+	})
+
+	t.Run("rejects incompatible decoder result", func(t *testing.T) {
+		type Level int
+		RegisterDecoder(Level(0), func(string) (interface{}, error) {
+			return "not a level", nil
+		})
+		defer UnregisterDecoder(Level(0))
+
+		type Config struct {
+			Level Level `ssm:"level"`
+		}
+
+		values := map[string]string{"level": "debug"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "incompatible type")
+	})
+}
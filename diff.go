@@ -0,0 +1,178 @@
+package ssmconfig
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// absentValue is used in a FieldChange for the side where a slice element or map entry doesn't exist.
+const absentValue = "<absent>"
+
+// FieldChange describes a single difference between two configuration snapshots.
+type FieldChange struct {
+	// Path is the location of the changed value, using dots for struct fields
+	// and brackets for slice indices and map keys (e.g. "Database.Hosts[0]", "Labels[env]").
+	Path string
+	// OldValue is the string representation of the previous value.
+	OldValue string
+	// NewValue is the string representation of the new value.
+	NewValue string
+}
+
+// stringerType is the type of fmt.Stringer, whose implementations Diff compares as single values.
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// Diff compares two configuration snapshots and returns the list of changed values.
+// Nested structs, pointers, slices, arrays, and maps are compared recursively.
+// Structs with unexported fields, an Equal method, or a String method (e.g. time.Time) are
+// compared as a whole with reflect.DeepEqual and rendered with fmt.Sprint.
+// A nil snapshot is treated as the zero value of T. Unexported fields of T itself are ignored,
+// but the exported fields of unexported embedded structs are compared under their promoted names.
+// Slice elements or map entries that exist on only one side are reported as "<absent>" on the other.
+func Diff[T any](oldConfig, newConfig *T) []FieldChange {
+	var zero T
+	if oldConfig == nil {
+		oldConfig = &zero
+	}
+	if newConfig == nil {
+		newConfig = &zero
+	}
+
+	var changes []FieldChange
+	oldVal, newVal := reflect.ValueOf(oldConfig).Elem(), reflect.ValueOf(newConfig).Elem()
+	if oldVal.Kind() == reflect.Struct {
+		diffFields("", oldVal, newVal, &changes)
+	} else {
+		diffValues("", oldVal, newVal, &changes)
+	}
+	return changes
+}
+
+//nolint:gocyclo // Complex function due to multiple reflect.Kind cases
+func diffValues(path string, oldVal, newVal reflect.Value, changes *[]FieldChange) {
+	//nolint:exhaustive // Scalar kinds are handled by the default case
+	switch oldVal.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if oldVal.IsNil() || newVal.IsNil() {
+			if oldVal.IsNil() != newVal.IsNil() {
+				addChange(changes, path, formatDiffValue(oldVal), formatDiffValue(newVal))
+			}
+			return
+		}
+		if oldVal.Kind() == reflect.Interface && oldVal.Elem().Type() != newVal.Elem().Type() {
+			addChange(changes, path, formatDiffValue(oldVal), formatDiffValue(newVal))
+			return
+		}
+		diffValues(path, oldVal.Elem(), newVal.Elem(), changes)
+
+	case reflect.Struct:
+		if isOpaqueStruct(oldVal.Type()) {
+			diffLeaf(path, oldVal, newVal, changes)
+			return
+		}
+		diffFields(path, oldVal, newVal, changes)
+
+	case reflect.Slice, reflect.Array:
+		length := oldVal.Len()
+		if newVal.Len() > length {
+			length = newVal.Len()
+		}
+		for i := 0; i < length; i++ {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= oldVal.Len():
+				addChange(changes, elemPath, absentValue, formatDiffValue(newVal.Index(i)))
+			case i >= newVal.Len():
+				addChange(changes, elemPath, formatDiffValue(oldVal.Index(i)), absentValue)
+			default:
+				diffValues(elemPath, oldVal.Index(i), newVal.Index(i), changes)
+			}
+		}
+
+	case reflect.Map:
+		keys := make(map[string]reflect.Value)
+		for _, key := range oldVal.MapKeys() {
+			keys[fmt.Sprintf("%v", key.Interface())] = key
+		}
+		for _, key := range newVal.MapKeys() {
+			keys[fmt.Sprintf("%v", key.Interface())] = key
+		}
+
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			key := keys[name]
+			elemPath := fmt.Sprintf("%s[%s]", path, name)
+			oldElem := oldVal.MapIndex(key)
+			newElem := newVal.MapIndex(key)
+			switch {
+			case !oldElem.IsValid():
+				addChange(changes, elemPath, absentValue, formatDiffValue(newElem))
+			case !newElem.IsValid():
+				addChange(changes, elemPath, formatDiffValue(oldElem), absentValue)
+			default:
+				diffValues(elemPath, oldElem, newElem, changes)
+			}
+		}
+
+	default:
+		diffLeaf(path, oldVal, newVal, changes)
+	}
+}
+
+// diffFields compares the exported fields of two struct values.
+func diffFields(path string, oldVal, newVal reflect.Value, changes *[]FieldChange) {
+	t := oldVal.Type()
+	for i := 0; i < oldVal.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			// The exported fields of an unexported embedded struct are promoted, so they're
+			// compared under the parent's path; other unexported fields are skipped
+			if field.Anonymous && field.Type.Kind() == reflect.Struct {
+				diffFields(path, oldVal.Field(i), newVal.Field(i), changes)
+			}
+			continue
+		}
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		diffValues(fieldPath, oldVal.Field(i), newVal.Field(i), changes)
+	}
+}
+
+// diffLeaf compares two values as a whole, recording a change if they differ.
+func diffLeaf(path string, oldVal, newVal reflect.Value, changes *[]FieldChange) {
+	if !reflect.DeepEqual(oldVal.Interface(), newVal.Interface()) {
+		addChange(changes, path, formatDiffValue(oldVal), formatDiffValue(newVal))
+	}
+}
+
+// isOpaqueStruct reports whether a struct type is compared as a single value rather than
+// field by field: its state is private, or it defines its own equality or representation.
+func isOpaqueStruct(t reflect.Type) bool {
+	if _, ok := t.MethodByName("Equal"); ok {
+		return true
+	}
+	return t.Implements(stringerType) || hasUnexportedFields(t)
+}
+
+func addChange(changes *[]FieldChange, path, oldValue, newValue string) {
+	*changes = append(*changes, FieldChange{Path: path, OldValue: oldValue, NewValue: newValue})
+}
+
+// formatDiffValue returns the string representation of a value, dereferencing pointers.
+func formatDiffValue(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "<nil>"
+		}
+		v = v.Elem()
+	}
+	return fmt.Sprint(v.Interface())
+}
@@ -0,0 +1,127 @@
+package ssmconfig
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	type Database struct {
+		Host string
+		Port int
+	}
+	type Config struct {
+		Name     string
+		Database Database
+		Cache    *Database
+		Hosts    []string
+		Labels   map[string]string
+		internal string
+	}
+
+	t.Run("returns no changes for equal configs", func(t *testing.T) {
+		oldConfig := &Config{Name: "app", Hosts: []string{"a"}, Labels: map[string]string{"env": "prod"}}
+		newConfig := &Config{Name: "app", Hosts: []string{"a"}, Labels: map[string]string{"env": "prod"}}
+
+		assert.Empty(t, Diff(oldConfig, newConfig))
+	})
+
+	t.Run("reports changed scalar and nested fields", func(t *testing.T) {
+		oldConfig := &Config{Name: "app", Database: Database{Host: "old-host", Port: 5432}}
+		newConfig := &Config{Name: "app", Database: Database{Host: "new-host", Port: 5433}}
+
+		changes := Diff(oldConfig, newConfig)
+		assert.Equal(t, []FieldChange{
+			{Path: "Database.Host", OldValue: "old-host", NewValue: "new-host"},
+			{Path: "Database.Port", OldValue: "5432", NewValue: "5433"},
+		}, changes)
+	})
+
+	t.Run("reports pointer changes", func(t *testing.T) {
+		oldConfig := &Config{}
+		newConfig := &Config{Cache: &Database{Host: "redis"}}
+
+		changes := Diff(oldConfig, newConfig)
+		assert.Equal(t, []FieldChange{
+			{Path: "Cache", OldValue: "<nil>", NewValue: "{redis 0}"},
+		}, changes)
+
+		changes = Diff(newConfig, &Config{Cache: &Database{Host: "memcached"}})
+		assert.Equal(t, []FieldChange{
+			{Path: "Cache.Host", OldValue: "redis", NewValue: "memcached"},
+		}, changes)
+	})
+
+	t.Run("reports slice changes by index", func(t *testing.T) {
+		oldConfig := &Config{Hosts: []string{"a", "b"}}
+		newConfig := &Config{Hosts: []string{"a", "c", "d"}}
+
+		changes := Diff(oldConfig, newConfig)
+		assert.Equal(t, []FieldChange{
+			{Path: "Hosts[1]", OldValue: "b", NewValue: "c"},
+			{Path: "Hosts[2]", OldValue: "<absent>", NewValue: "d"},
+		}, changes)
+	})
+
+	t.Run("reports map changes by key", func(t *testing.T) {
+		oldConfig := &Config{Labels: map[string]string{"env": "prod", "team": "core"}}
+		newConfig := &Config{Labels: map[string]string{"env": "staging", "region": "eu"}}
+
+		changes := Diff(oldConfig, newConfig)
+		assert.Equal(t, []FieldChange{
+			{Path: "Labels[env]", OldValue: "prod", NewValue: "staging"},
+			{Path: "Labels[region]", OldValue: "<absent>", NewValue: "eu"},
+			{Path: "Labels[team]", OldValue: "core", NewValue: "<absent>"},
+		}, changes)
+	})
+
+	t.Run("ignores unexported fields", func(t *testing.T) {
+		oldConfig := &Config{internal: "a"}
+		newConfig := &Config{internal: "b"}
+
+		assert.Empty(t, Diff(oldConfig, newConfig))
+	})
+
+	t.Run("compares structs with private state as values", func(t *testing.T) {
+		type Schedule struct {
+			Start    time.Time
+			Deadline *time.Time
+		}
+
+		start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		deadline := start.Add(time.Hour)
+		oldConfig := &Schedule{Start: start, Deadline: &deadline}
+		newConfig := &Schedule{Start: start.Add(time.Minute), Deadline: &deadline}
+
+		changes := Diff(oldConfig, newConfig)
+		assert.Equal(t, []FieldChange{
+			{Path: "Start", OldValue: fmt.Sprint(start), NewValue: fmt.Sprint(start.Add(time.Minute))},
+		}, changes)
+		assert.Empty(t, Diff(oldConfig, &Schedule{Start: start, Deadline: &deadline}))
+	})
+
+	t.Run("compares exported fields of unexported embedded structs", func(t *testing.T) {
+		type base struct {
+			Region string
+		}
+		type Embedding struct {
+			base
+			Name string
+		}
+
+		changes := Diff(&Embedding{base: base{Region: "eu"}}, &Embedding{base: base{Region: "us"}})
+		assert.Equal(t, []FieldChange{
+			{Path: "Region", OldValue: "eu", NewValue: "us"},
+		}, changes)
+	})
+
+	t.Run("treats nil snapshot as zero value", func(t *testing.T) {
+		changes := Diff(nil, &Config{Name: "app"})
+		assert.Equal(t, []FieldChange{
+			{Path: "Name", OldValue: "", NewValue: "app"},
+		}, changes)
+	})
+}
@@ -0,0 +1,48 @@
+package ssmconfig
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	encodingBase64     = "base64"
+	encodingGzipBase64 = "gzip+base64"
+)
+
+// decodeValue undoes the encoding named by the encoding tag before the value is converted.
+// Supported encodings are "base64" and "gzip+base64" (base64 of gzip-compressed data).
+// Errors name the field and the stage that failed.
+func decodeValue(value, encoding, fieldName string) (string, error) {
+	switch encoding {
+	case "":
+		return value, nil
+	case encodingBase64, encodingGzipBase64:
+	default:
+		return "", fmt.Errorf("unsupported encoding '%s' for field '%s'", encoding, fieldName)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil {
+		return "", fmt.Errorf("base64 decoding field '%s': %w", fieldName, err)
+	}
+
+	if encoding == encodingGzipBase64 {
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return "", fmt.Errorf("gunzipping field '%s': %w", fieldName, err)
+		}
+		defer reader.Close()
+
+		data, err = io.ReadAll(reader)
+		if err != nil {
+			return "", fmt.Errorf("gunzipping field '%s': %w", fieldName, err)
+		}
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,83 @@
+package ssmconfig
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gzipBase64(t *testing.T, value string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(value))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestMapToStruct_Encoding(t *testing.T) {
+	type Database struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+
+	type Config struct {
+		Database Database          `ssm:"database" json:"true" encoding:"gzip+base64"`
+		Labels   map[string]string `ssm:"labels" json:"1" encoding:"gzip+base64"`
+		Cert     string            `ssm:"cert" encoding:"base64"`
+	}
+
+	t.Run("decodes gzip+base64 JSON and base64 strings", func(t *testing.T) {
+		values := map[string]string{
+			"database": gzipBase64(t, `{"host":"db.example.com","port":5432}`),
+			"labels":   gzipBase64(t, `{"team":"platform"}`),
+			"cert":     base64.StdEncoding.EncodeToString([]byte("-----BEGIN CERTIFICATE-----")),
+		}
+
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, Database{Host: "db.example.com", Port: 5432}, result.Database)
+		assert.Equal(t, map[string]string{"team": "platform"}, result.Labels)
+		assert.Equal(t, "-----BEGIN CERTIFICATE-----", result.Cert)
+	})
+
+	t.Run("names the field and stage on base64 errors", func(t *testing.T) {
+		var result Config
+		err := mapToStruct(map[string]string{"database": "not base64!"}, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "base64 decoding field 'Database'")
+	})
+
+	t.Run("names the field and stage on gzip errors", func(t *testing.T) {
+		values := map[string]string{"labels": base64.StdEncoding.EncodeToString([]byte("plain"))}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "gunzipping field 'Labels'")
+	})
+
+	t.Run("names the field on JSON errors", func(t *testing.T) {
+		values := map[string]string{"labels": gzipBase64(t, "not json")}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "decoding JSON for field Labels")
+	})
+
+	t.Run("rejects unknown encodings", func(t *testing.T) {
+		type BadConfig struct {
+			Value string `ssm:"value" encoding:"hex"`
+		}
+
+		var result BadConfig
+		err := mapToStruct(map[string]string{"value": "00"}, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported encoding 'hex' for field 'Value'")
+	})
+}
@@ -0,0 +1,149 @@
+package ssmconfig
+
+import (
+	"context"
+	"reflect"
+	"strings"
+)
+
+// missingValueReason is the report error of a required field that no source provided.
+const missingValueReason = "required value missing"
+
+// FieldReport describes how a single field was resolved, as returned by Explain.
+type FieldReport struct {
+	// Field is the dotted Go field path (e.g. "Database.Host", "Servers[0].Name").
+	Field string
+	// Label is the field's label tag (e.g. "Database Host"), or its Go field name without one.
+	Label string
+	// Key is the full SSM parameter path, or empty for fields without an SSM key.
+	Key string
+	// Env is the env tag: the environment variable(s) that override the parameter, if any.
+	Env string
+	// Required reports whether the field is marked as required.
+	Required bool
+	// Source is where the value came from, or SourceNone if no source provided one.
+	Source ValueSource
+	// Valid reports whether the value was present if required, converted, and passed validation.
+	Valid bool
+	// Error describes why the field is not valid.
+	Error string
+}
+
+// Explain loads the configuration for T like Load, but instead of the struct returns a report
+// per field: its key, whether it's required, the source its value came from, and whether
+// conversion and validation passed. Field errors and missing required fields are recorded in
+// the report rather than failing the call, so it suits a /debug/config handler. An error is
+// returned only if the values can't be loaded. Reports carry no values, but field errors
+// may quote the value that failed to convert or validate.
+func Explain[T any](ctx context.Context, prefix string, opts ...LoaderOption) ([]FieldReport, error) {
+	loader, err := NewLoader(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ExplainWithLoader[T](loader, ctx, prefix)
+}
+
+// ExplainWithLoader is Explain using an existing Loader instance.
+func ExplainWithLoader[T any](loader *Loader, ctx context.Context, prefix string) ([]FieldReport, error) {
+	values, err := loader.loadValues(ctx, []string{prefix})
+	if err != nil {
+		return nil, err
+	}
+
+	opts := loader.mapOptions()
+	opts.parameterTypes = values.parameterTypes
+	opts.valueSources = values.sources
+	// Every field is reported, so errors are collected and missing required fields don't panic
+	opts.strict = false
+	opts.collectErrors = true
+
+	var result T
+	m := &mapper{
+		opts:           opts,
+		parameterTypes: opts.parameterTypes,
+		rootValues:     values.values,
+		valueSources:   opts.valueSources,
+		keyPrefix:      strings.TrimRight(loader.ssmPrefix(prefix), "/"),
+		explain:        true,
+		validators:     snapshotValidators(),
+	}
+	if err := m.mapStruct(values.values, &result, ""); err != nil {
+		return nil, err
+	}
+	return m.reports, nil
+}
+
+// record adds a report entry for a resolved field when explaining a load.
+func (m *mapper) record(field reflect.StructField, fieldPath, ssmTag, envTag string, source ValueSource) {
+	if !m.explain {
+		return
+	}
+	key := ""
+	if ssmTag != "" {
+		key = joinKey(m.keyPrefix, ssmTag)
+	}
+	// With WithGlobalPrefix, report the environment variables actually read
+	if envTag != "" && m.opts.envPrefix != "" {
+		envTag = strings.Join(m.envNames(envTag), ",")
+	}
+	m.reports = append(m.reports, FieldReport{
+		Field:    fieldPath,
+		Label:    fieldLabel(field),
+		Key:      key,
+		Env:      envTag,
+		Required: isRequiredField(field.Tag.Get("required")),
+		Source:   source,
+		Valid:    true,
+	})
+}
+
+// recordFailure marks the report entry of a field as not valid, adding an entry if the
+// field has none yet (e.g. a nested struct whose validator failed).
+func (m *mapper) recordFailure(fieldPath, reason string) {
+	if !m.explain {
+		return
+	}
+	if n := len(m.reports); n > 0 && m.reports[n-1].Field == fieldPath {
+		m.reports[n-1].Valid = false
+		m.reports[n-1].Error = reason
+		return
+	}
+	m.reports = append(m.reports, FieldReport{Field: fieldPath, Label: m.labels[fieldPath], Error: reason})
+}
+
+// recordLabel remembers the label of a field when explaining a load, for report entries
+// added by recordFailure.
+func (m *mapper) recordLabel(fieldPath, label string) {
+	if !m.explain {
+		return
+	}
+	if m.labels == nil {
+		m.labels = make(map[string]string)
+	}
+	m.labels[fieldPath] = label
+}
+
+// valueSource returns the source of a value found by lookupValue.
+func (m *mapper) valueSource(ssmTag string, hasValue, fromValues bool) ValueSource {
+	switch {
+	case !hasValue:
+		return SourceNone
+	case !fromValues:
+		return SourceEnv
+	case m.valueSources[ssmTag] == string(SourceFile):
+		return SourceFile
+	case m.valueSources[ssmTag] == string(SourceVault):
+		return SourceVault
+	default:
+		return SourceSSM
+	}
+}
+
+// joinKey joins a key prefix and a key with a slash.
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "/" + key
+}
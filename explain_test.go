@@ -0,0 +1,113 @@
+package ssmconfig
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainWithLoader(t *testing.T) {
+	type Server struct {
+		Name string `ssm:"name"`
+	}
+	type Config struct {
+		Host     string `ssm:"host" env:"TEST_EXPLAIN_HOST"`
+		Port     int    `ssm:"port"`
+		LogLevel string `ssm:"log_level" validate:"oneof:debug|info"`
+		Timeout  int    `ssm:"timeout"`
+		APIKey   string `ssm:"api_key" required:"true"`
+		Region   string `env:"TEST_EXPLAIN_REGION"`
+		Database struct {
+			User string `ssm:"user"`
+		} `ssm:"database"`
+		Servers []Server `ssm:"servers"`
+	}
+
+	t.Setenv("TEST_EXPLAIN_HOST", "env.example.com")
+	client := newMockSSMClient(map[string]string{
+		"/myapp/host":           "ssm.example.com",
+		"/myapp/port":           "not-a-number",
+		"/myapp/log_level":      "trace",
+		"/myapp/database/user":  "admin",
+		"/myapp/servers/0/name": "a",
+		"/myapp/servers/2/name": "c",
+	})
+	loader := newLoader(client, WithConfigBytes("yaml", []byte("timeout: 30\n")))
+
+	reports, err := ExplainWithLoader[Config](loader, context.Background(), "/myapp/")
+	require.NoError(t, err)
+
+	assert.Equal(t, []FieldReport{
+		{Field: "Host", Label: "Host", Key: "/myapp/host", Env: "TEST_EXPLAIN_HOST", Source: SourceEnv, Valid: true},
+		{Field: "Port", Label: "Port", Key: "/myapp/port", Source: SourceSSM,
+			Error: `setting field Port: invalid int value: strconv.ParseInt: parsing "not-a-number": invalid syntax`},
+		{Field: "LogLevel", Label: "LogLevel", Key: "/myapp/log_level", Source: SourceSSM,
+			Error: "validation failed for field 'LogLevel' using validator 'oneof:debug|info': " +
+				"value trace is not one of [debug|info]"},
+		{Field: "Timeout", Label: "Timeout", Key: "/myapp/timeout", Source: SourceFile, Valid: true},
+		{Field: "APIKey", Label: "APIKey", Key: "/myapp/api_key", Required: true, Source: SourceNone,
+			Error: missingValueReason},
+		{Field: "Region", Label: "Region", Env: "TEST_EXPLAIN_REGION", Source: SourceNone, Valid: true},
+		{Field: "Database.User", Label: "User", Key: "/myapp/database/user", Source: SourceSSM, Valid: true},
+		{Field: "Servers[0].Name", Label: "Name", Key: "/myapp/servers/0/name", Source: SourceSSM, Valid: true},
+		{Field: "Servers[1].Name", Label: "Name", Key: "/myapp/servers/2/name", Source: SourceSSM, Valid: true},
+	}, reports)
+}
+
+func TestExplainWithLoader_StrictModeDoesNotFail(t *testing.T) {
+	type Config struct {
+		Database struct {
+			Host string `ssm:"host"`
+		} `ssm:"database" required:"true"`
+	}
+
+	loader := newLoader(newMockSSMClient(map[string]string{}), WithStrictMode(true))
+
+	reports, err := ExplainWithLoader[Config](loader, context.Background(), "/myapp/")
+	require.NoError(t, err)
+	assert.Equal(t, []FieldReport{
+		{Field: "Database", Label: "Database", Required: true, Source: SourceNone, Error: missingValueReason},
+	}, reports)
+}
+
+func TestExplainWithLoader_Labels(t *testing.T) {
+	type Config struct {
+		Host     string `ssm:"host" label:"Database Host"`
+		Database struct {
+			Port int `ssm:"port" label:"Database Port"`
+		} `ssm:"database" validate:"reachable" label:"Database Settings"`
+	}
+	RegisterValidator("reachable", func(interface{}) error { return errors.New("unreachable database") })
+	defer UnregisterValidator("reachable")
+
+	loader := newLoader(newMockSSMClient(map[string]string{"/myapp/host": "db", "/myapp/database/port": "x"}))
+
+	reports, err := ExplainWithLoader[Config](loader, context.Background(), "/myapp/")
+	require.NoError(t, err)
+	require.Len(t, reports, 3)
+	assert.Equal(t, "Database Host", reports[0].Label)
+	assert.Equal(t, "Database.Port", reports[1].Field)
+	assert.Equal(t, "Database Port", reports[1].Label)
+	assert.Contains(t, reports[1].Error, "setting field Database Port: invalid int value")
+	// The nested struct only gets an entry when its validator fails
+	assert.Equal(t, FieldReport{Field: "Database", Label: "Database Settings",
+		Error: "validation failed for field 'Database Settings' using validator 'reachable': unreachable database"},
+		reports[2])
+}
+
+func TestExplainWithLoader_LoadError(t *testing.T) {
+	type Config struct {
+		Host string `ssm:"host"`
+	}
+
+	client := newMockSSMClient(map[string]string{})
+	client.err = errors.New("access denied")
+
+	reports, err := ExplainWithLoader[Config](newLoader(client), context.Background(), "/myapp/")
+	require.Error(t, err)
+	assert.Nil(t, reports)
+	assert.Contains(t, err.Error(), "access denied")
+}
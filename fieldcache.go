@@ -0,0 +1,92 @@
+package ssmconfig
+
+import (
+	"reflect"
+	"sync"
+)
+
+// fieldMeta is the tag-derived metadata of a struct field, computed once per struct type
+// and tag name so repeated maps of the same type (e.g. on every auto-refresh) skip tag parsing.
+// Anything that depends on loader options (implicit keys, key case) or on registries that
+// can change at runtime (decoders, validators) is still resolved on each map.
+type fieldMeta struct {
+	field        reflect.StructField
+	fieldType    reflect.Type // The field's type, dereferenced if it's a pointer
+	ssmTag       string       // As written, before implicit keys and case folding
+	squash       bool
+	envTag       string
+	requiredTag  string
+	jsonTag      string
+	validateTag  string
+	transformTag string
+	encodingTag  string
+	inParamTag   string
+	defaultTag   string
+	gateTag      string
+	whenTag      string
+	fromFile     bool
+	label        string // Name used in messages and reports (see fieldLabel)
+	sources      []ValueSource
+	sourcesErr   error
+	base         int
+	baseErr      error
+}
+
+// fieldMetaKey identifies a cached field list: the same type read with a different tag
+// name (see WithTagName) has different keys.
+type fieldMetaKey struct {
+	typ     reflect.Type
+	tagName string
+}
+
+// fieldMetaCache maps fieldMetaKey to []fieldMeta.
+var fieldMetaCache sync.Map
+
+// structFields returns the metadata of each field of the struct type, in field order.
+// The result is shared and must not be modified.
+func structFields(typ reflect.Type, tagName string) []fieldMeta {
+	key := fieldMetaKey{typ: typ, tagName: tagName}
+	if cached, ok := fieldMetaCache.Load(key); ok {
+		return cached.([]fieldMeta)
+	}
+
+	fields := make([]fieldMeta, typ.NumField())
+	for i := range fields {
+		field := typ.Field(i)
+		ssmTag, squash := parseSSMTag(field, tagName)
+		label := fieldLabel(field)
+		sources, sourcesErr := parseSourceTag(field.Tag.Get("source"), label)
+		base, baseErr := parseBaseTag(field.Tag.Get("base"), label)
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		fields[i] = fieldMeta{
+			field:        field,
+			fieldType:    fieldType,
+			ssmTag:       ssmTag,
+			squash:       squash,
+			envTag:       field.Tag.Get("env"),
+			requiredTag:  field.Tag.Get("required"),
+			jsonTag:      field.Tag.Get("json"),
+			validateTag:  field.Tag.Get("validate"),
+			transformTag: field.Tag.Get("transform"),
+			encodingTag:  field.Tag.Get("encoding"),
+			inParamTag:   field.Tag.Get("inparam"),
+			defaultTag:   field.Tag.Get("default"),
+			gateTag:      field.Tag.Get("gate"),
+			whenTag:      field.Tag.Get("when"),
+			fromFile:     isFromFileTag(field.Tag.Get("fromfile")),
+			label:        label,
+			sources:      sources,
+			sourcesErr:   sourcesErr,
+			base:         base,
+			baseErr:      baseErr,
+		}
+	}
+
+	actual, _ := fieldMetaCache.LoadOrStore(key, fields)
+	return actual.([]fieldMeta)
+}
@@ -0,0 +1,110 @@
+package ssmconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructFields(t *testing.T) {
+	type Config struct {
+		Host    string `ssm:"host" env:"HOST" required:"true" label:"API host"`
+		Port    int    `ssm:"port" base:"1"`
+		Mode    string `ssm:"mode" source:"bogus"`
+		private string //nolint:unused // Unexported fields are listed but never set
+	}
+
+	t.Run("parses tags once per type", func(t *testing.T) {
+		typ := reflect.TypeOf(Config{})
+		fields := structFields(typ, "")
+		require.Len(t, fields, 4)
+		assert.Same(t, &fields[0], &structFields(typ, "")[0])
+
+		host := fields[0]
+		assert.Equal(t, "host", host.ssmTag)
+		assert.Equal(t, "HOST", host.envTag)
+		assert.Equal(t, "true", host.requiredTag)
+		assert.Equal(t, "API host", host.label)
+		assert.Equal(t, defaultSources, host.sources)
+		assert.Equal(t, 10, host.base)
+
+		require.Error(t, fields[1].baseErr)
+		assert.Contains(t, fields[1].baseErr.Error(), "invalid base '1' for field Port")
+		require.Error(t, fields[2].sourcesErr)
+	})
+
+	t.Run("caches each tag name separately", func(t *testing.T) {
+		type Tagged struct {
+			Host string `ssm:"host" param:"hostname"`
+		}
+		typ := reflect.TypeOf(Tagged{})
+
+		assert.Equal(t, "host", structFields(typ, "")[0].ssmTag)
+		assert.Equal(t, "hostname", structFields(typ, "param")[0].ssmTag)
+	})
+
+	t.Run("repeated maps give the same result", func(t *testing.T) {
+		values := map[string]string{"host": "localhost", "mode": "dev"}
+		for i := 0; i < 3; i++ {
+			var result struct {
+				Host string `ssm:"host"`
+				Mode string `ssm:"mode"`
+			}
+			require.NoError(t, mapToStruct(values, &result, false, nil, true))
+			assert.Equal(t, "localhost", result.Host)
+			assert.Equal(t, "dev", result.Mode)
+		}
+	})
+}
+
+// benchmarkConfig builds a 50-field struct type (alternating string and int fields) and
+// values for all of its fields.
+func benchmarkConfig() (reflect.Type, map[string]string) {
+	fields := make([]reflect.StructField, 50)
+	values := make(map[string]string, len(fields))
+	for i := range fields {
+		key := fmt.Sprintf("field_%d", i)
+		typ, val := reflect.TypeOf(""), "value"
+		if i%2 == 1 {
+			typ, val = reflect.TypeOf(0), "42"
+		}
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("Field%d", i),
+			Type: typ,
+			Tag:  reflect.StructTag(fmt.Sprintf(`ssm:"%s" env:"%s" validate:"" required:"true"`, key, strings.ToUpper(key))),
+		}
+		values[key] = val
+	}
+	return reflect.StructOf(fields), values
+}
+
+// BenchmarkMapToStruct maps a 50-field struct, with and without the field metadata cache.
+// Run with -benchtime=10000x to map it 10k times, as an auto-refreshing service would.
+func BenchmarkMapToStruct(b *testing.B) {
+	typ, values := benchmarkConfig()
+
+	b.Run("cached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			dest := reflect.New(typ).Interface()
+			if err := mapToStruct(values, dest, false, nil, true); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			fieldMetaCache.Clear()
+			dest := reflect.New(typ).Interface()
+			if err := mapToStruct(values, dest, false, nil, true); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
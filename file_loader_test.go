@@ -2,9 +2,11 @@ package ssmconfig
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -57,7 +59,7 @@ func TestLoader_LoadFromFiles(t *testing.T) {
 		loader, err := NewLoader(ctx)
 		require.NoError(t, err)
 
-		values := loader.loadFromFiles()
+		values := loader.loadFromFiles(context.Background())
 		assert.Empty(t, values)
 	})
 
@@ -73,7 +75,7 @@ func TestLoader_LoadFromFiles(t *testing.T) {
 		loader, err := NewLoader(ctx, WithConfigFiles("nonexistent.yaml"))
 		require.NoError(t, err)
 
-		values := loader.loadFromFiles()
+		values := loader.loadFromFiles(context.Background())
 		assert.Empty(t, values)
 	})
 
@@ -102,7 +104,7 @@ server:
 		loader, err := NewLoader(ctx, WithConfigFiles(yamlFile))
 		require.NoError(t, err)
 
-		values := loader.loadFromFiles()
+		values := loader.loadFromFiles(context.Background())
 		assert.Equal(t, "postgres://localhost:5432/mydb", values["database/url"])
 		assert.Equal(t, "5432", values["database/port"])
 		assert.Equal(t, "0.0.0.0", values["server/host"])
@@ -136,7 +138,7 @@ server:
 		loader, err := NewLoader(ctx, WithConfigFiles(jsonFile))
 		require.NoError(t, err)
 
-		values := loader.loadFromFiles()
+		values := loader.loadFromFiles(context.Background())
 		assert.Equal(t, "postgres://localhost:5432/mydb", values["database/url"])
 		assert.Equal(t, "5432", values["database/port"])
 	})
@@ -167,7 +169,7 @@ port = 8080
 		loader, err := NewLoader(ctx, WithConfigFiles(tomlFile))
 		require.NoError(t, err)
 
-		values := loader.loadFromFiles()
+		values := loader.loadFromFiles(context.Background())
 		assert.Equal(t, "postgres://localhost:5432/mydb", values["database/url"])
 		assert.Equal(t, "5432", values["database/port"])
 	})
@@ -201,7 +203,7 @@ database:
 		loader, err := NewLoader(ctx, WithConfigFiles(file1, file2))
 		require.NoError(t, err)
 
-		values := loader.loadFromFiles()
+		values := loader.loadFromFiles(context.Background())
 		// file2 should override file1
 		assert.Equal(t, "file2-url", values["database/url"])
 		// port from file1 should still be present
@@ -229,11 +231,52 @@ database:
 		loader, err := NewLoader(ctx, WithConfigFiles(invalidFile), WithLogger(logger))
 		require.NoError(t, err)
 
-		values := loader.loadFromFiles()
+		values := loader.loadFromFiles(context.Background())
 		// Should not error, just skip invalid file
 		assert.Empty(t, values)
 		assert.Len(t, loggedMessages, 1)
 	})
+
+	t.Run("reads files from the configured filesystem", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"config/base.yaml":  {Data: []byte("database:\n  url: base-url\n  port: 5432\n")},
+			"config/local.json": {Data: []byte(`{"database": {"url": "local-url"}}`)},
+		}
+
+		loader := newLoader(nil, WithConfigFS(fsys),
+			WithConfigFiles("config/base.yaml", "config/local.json", "config/missing.toml"))
+
+		values := loader.loadFromFiles(context.Background())
+		assert.Equal(t, "local-url", values["database/url"])
+		assert.Equal(t, "5432", values["database/port"])
+	})
+
+	t.Run("reads raw config documents after files", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"config.yaml": {Data: []byte("database:\n  url: file-url\n  port: 5432\n")},
+		}
+
+		loader := newLoader(nil, WithConfigFS(fsys), WithConfigFiles("config.yaml"),
+			WithConfigBytes("json", []byte(`{"database": {"url": "json-url"}, "server": {"port": 8080}}`)),
+			WithConfigBytes("TOML", []byte("[server]\nport = 9090\n")))
+
+		values := loader.loadFromFiles(context.Background())
+		assert.Equal(t, "json-url", values["database/url"])
+		assert.Equal(t, "5432", values["database/port"])
+		assert.Equal(t, "9090", values["server/port"])
+	})
+
+	t.Run("skips raw config documents in unknown formats", func(t *testing.T) {
+		var loggedMessages []string
+		logger := func(format string, args ...interface{}) {
+			loggedMessages = append(loggedMessages, format)
+		}
+		loader := newLoader(nil, WithLogger(logger), WithConfigBytes("ini", []byte("key=value")))
+
+		values := loader.loadFromFiles(context.Background())
+		assert.Empty(t, values)
+		assert.Len(t, loggedMessages, 1)
+	})
 }
 
 func TestLoadWithConfigFiles(t *testing.T) {
@@ -267,7 +310,7 @@ database:
 		loader, err := NewLoader(ctx, WithConfigFiles(yamlFile))
 		require.NoError(t, err)
 
-		fileValues := loader.loadFromFiles()
+		fileValues := loader.loadFromFiles(context.Background())
 		assert.Equal(t, "file-url", fileValues["database/url"])
 
 		// In actual usage, ENV would override this in mapToStruct
@@ -297,7 +340,7 @@ value: "file-value"
 
 		// Simulate SSM values
 		ssmValues := map[string]string{"value": "ssm-value"}
-		fileValues := loader.loadFromFiles()
+		fileValues := loader.loadFromFiles(context.Background())
 
 		// Merge: file should override SSM
 		merged := make(map[string]string)
@@ -312,6 +355,74 @@ value: "file-value"
 	})
 }
 
+func TestSourcePriority_NestedLeaf(t *testing.T) {
+	type Config struct {
+		Database struct {
+			Host string `ssm:"host" env:"TEST_PRIORITY_DB_HOST"`
+			Port int    `ssm:"port"`
+		} `ssm:"database" required:"true"`
+	}
+
+	fsys := fstest.MapFS{
+		"config.yaml": {Data: []byte("database:\n  host: file-host\n")},
+	}
+	client := newMockSSMClient(map[string]string{
+		"/test/database/host": "ssm-host",
+		"/test/database/port": "5432",
+	})
+
+	t.Run("env wins over file and ssm", func(t *testing.T) {
+		t.Setenv("TEST_PRIORITY_DB_HOST", "env-host")
+		loader := newLoader(client, WithConfigFS(fsys), WithConfigFiles("config.yaml"))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "env-host", cfg.Database.Host)
+		assert.Equal(t, 5432, cfg.Database.Port)
+	})
+
+	t.Run("file wins over ssm", func(t *testing.T) {
+		loader := newLoader(client, WithConfigFS(fsys), WithConfigFiles("config.yaml"))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "file-host", cfg.Database.Host)
+		assert.Equal(t, 5432, cfg.Database.Port)
+	})
+
+	t.Run("ssm is used when no other source is set", func(t *testing.T) {
+		loader := newLoader(client)
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "ssm-host", cfg.Database.Host)
+	})
+
+	t.Run("env alone satisfies a required nested struct", func(t *testing.T) {
+		t.Setenv("TEST_PRIORITY_DB_HOST", "env-host")
+		loader := newLoader(newMockSSMClient(map[string]string{}), WithStrictMode(true))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "env-host", cfg.Database.Host)
+	})
+
+	t.Run("self-referential required structs are reported as missing", func(t *testing.T) {
+		type probeNode struct {
+			Name string     `ssm:"name" env:"TEST_PRIORITY_NODE_NAME"`
+			Next *probeNode `ssm:"next"`
+		}
+		type Tree struct {
+			Root probeNode `ssm:"root" required:"true"`
+		}
+		loader := newLoader(newMockSSMClient(map[string]string{}), WithStrictMode(true))
+
+		_, err := LoadWithLoader[Tree](loader, context.Background(), "/test/")
+		require.ErrorIs(t, err, ErrMissingRequired)
+		assert.Contains(t, err.Error(), "'Root'")
+	})
+}
+
 func TestFileConfig_NestedStructs(t *testing.T) {
 	t.Run("loads nested struct from YAML file", func(t *testing.T) {
 		type Config struct {
@@ -352,7 +463,7 @@ server:
 		require.NoError(t, err)
 
 		// Load from file
-		fileValues := loader.loadFromFiles()
+		fileValues := loader.loadFromFiles(context.Background())
 
 		// Verify file values are loaded correctly
 		assert.Equal(t, "localhost", fileValues["database/host"])
@@ -402,7 +513,7 @@ server:
 		loader, err := NewLoader(ctx, WithConfigFiles(jsonFile))
 		require.NoError(t, err)
 
-		fileValues := loader.loadFromFiles()
+		fileValues := loader.loadFromFiles(context.Background())
 
 		var cfg Config
 		err = mapToStruct(fileValues, &cfg, false, nil, true)
@@ -450,7 +561,7 @@ app:
 		loader, err := NewLoader(ctx, WithConfigFiles(yamlFile))
 		require.NoError(t, err)
 
-		fileValues := loader.loadFromFiles()
+		fileValues := loader.loadFromFiles(context.Background())
 
 		var cfg Config
 		err = mapToStruct(fileValues, &cfg, false, nil, true)
@@ -462,3 +573,226 @@ app:
 		assert.Equal(t, 8080, cfg.App.Server.Port)
 	})
 }
+
+func TestLoader_LoadFromFiles_YAMLAnchors(t *testing.T) {
+	t.Run("resolves anchors and merge keys", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		yamlFile := filepath.Join(tmpDir, "config.yaml")
+		err := os.WriteFile(yamlFile, []byte(`
+defaults: &defaults
+  host: "localhost"
+  port: 5432
+  timeout: 30
+primary:
+  <<: *defaults
+  host: "primary.db"
+replica:
+  <<: *defaults
+  port: 5433
+region: &region "eu-west-1"
+backup_region: *region
+`), 0644)
+		require.NoError(t, err)
+
+		loader := newLoader(nil, WithConfigFiles(yamlFile))
+
+		values := loader.loadFromFiles(context.Background())
+		assert.Equal(t, "primary.db", values["primary/host"])
+		assert.Equal(t, "5432", values["primary/port"])
+		assert.Equal(t, "30", values["primary/timeout"])
+		assert.Equal(t, "localhost", values["replica/host"])
+		assert.Equal(t, "5433", values["replica/port"])
+		assert.Equal(t, "30", values["replica/timeout"])
+		assert.Equal(t, "eu-west-1", values["backup_region"])
+		_, hasMergeKey := values["primary/<<"]
+		assert.False(t, hasMergeKey)
+	})
+}
+
+func TestLoader_LoadFromFiles_TOMLArrayOfTables(t *testing.T) {
+	tmpDir := t.TempDir()
+	tomlFile := filepath.Join(tmpDir, "config.toml")
+	err := os.WriteFile(tomlFile, []byte(`
+name = "cluster"
+
+[[servers]]
+name = "alpha"
+port = 8080
+
+[[servers]]
+name = "beta"
+port = 8081
+`), 0644)
+	require.NoError(t, err)
+
+	t.Run("flattens array of tables into indexed keys", func(t *testing.T) {
+		loader := newLoader(nil, WithConfigFiles(tomlFile))
+
+		values := loader.loadFromFiles(context.Background())
+		assert.Equal(t, "cluster", values["name"])
+		assert.Equal(t, "alpha", values["servers/0/name"])
+		assert.Equal(t, "8080", values["servers/0/port"])
+		assert.Equal(t, "beta", values["servers/1/name"])
+		assert.Equal(t, "8081", values["servers/1/port"])
+		assert.JSONEq(t, `[{"name":"alpha","port":8080},{"name":"beta","port":8081}]`, values["servers"])
+	})
+
+	t.Run("maps array of tables into json-tagged slice field", func(t *testing.T) {
+		type Server struct {
+			Name string `json:"name"`
+			Port int    `json:"port"`
+		}
+		type Config struct {
+			Name    string   `ssm:"name"`
+			Servers []Server `ssm:"servers" json:"true"`
+		}
+
+		loader := newLoader(newMockSSMClient(map[string]string{}), WithConfigFiles(tomlFile))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "cluster", cfg.Name)
+		assert.Equal(t, []Server{{Name: "alpha", Port: 8080}, {Name: "beta", Port: 8081}}, cfg.Servers)
+	})
+}
+
+func TestWithConfigFileBase(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config/app.yaml":      {Data: []byte("host: base.example.com\nport: 5432\n")},
+		"config/app.prod.yaml": {Data: []byte("host: prod.example.com\n")},
+		"config/app.dev.json":  {Data: []byte(`{"port": 6543}`)},
+	}
+
+	t.Run("loads the base file only", func(t *testing.T) {
+		loader := newLoader(newMockSSMClient(map[string]string{}),
+			WithConfigFS(fsys), WithConfigFileBase("config/app"))
+
+		assert.Equal(t, map[string]string{"host": "base.example.com", "port": "5432"},
+			loader.loadFromFiles(context.Background()))
+	})
+
+	t.Run("overlays the environment file on the base file", func(t *testing.T) {
+		loader := newLoader(newMockSSMClient(map[string]string{}),
+			WithConfigFS(fsys), WithConfigFileBase("config/app"), WithEnvironment("prod"))
+
+		assert.Equal(t, map[string]string{"host": "prod.example.com", "port": "5432"},
+			loader.loadFromFiles(context.Background()))
+	})
+
+	t.Run("overlay may use a different format", func(t *testing.T) {
+		loader := newLoader(newMockSSMClient(map[string]string{}),
+			WithConfigFS(fsys), WithConfigFileBase("config/app"), WithEnvironment("dev"))
+
+		assert.Equal(t, map[string]string{"host": "base.example.com", "port": "6543"},
+			loader.loadFromFiles(context.Background()))
+	})
+
+	t.Run("missing environment overlay is skipped", func(t *testing.T) {
+		loader := newLoader(newMockSSMClient(map[string]string{}),
+			WithConfigFS(fsys), WithConfigFileBase("config/app"), WithEnvironment("staging"))
+
+		assert.Equal(t, map[string]string{"host": "base.example.com", "port": "5432"},
+			loader.loadFromFiles(context.Background()))
+	})
+
+	t.Run("explicit config files override the convention files", func(t *testing.T) {
+		files := fstest.MapFS{
+			"config/app.yaml":      fsys["config/app.yaml"],
+			"config/app.prod.yaml": fsys["config/app.prod.yaml"],
+			"local.yaml":           {Data: []byte("host: localhost\n")},
+		}
+		loader := newLoader(newMockSSMClient(map[string]string{}),
+			WithConfigFS(files), WithConfigFileBase("config/app"), WithEnvironment("prod"),
+			WithConfigFiles("local.yaml"))
+
+		assert.Equal(t, map[string]string{"host": "localhost", "port": "5432"}, loader.loadFromFiles(context.Background()))
+	})
+}
+
+func TestWithWarnOnFileConflict(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config/app.yaml":      {Data: []byte("host: base.example.com\nport: 5432\ndatabase:\n  user: app\n")},
+		"config/app.prod.yaml": {Data: []byte("host: prod.example.com\nport: 5432\ndatabase:\n  user: prod\n")},
+	}
+
+	newLogger := func(logged *[]string) LoaderOption {
+		return WithLogger(func(format string, args ...interface{}) {
+			*logged = append(*logged, fmt.Sprintf(format, args...))
+		})
+	}
+
+	t.Run("warns for each overridden key with both values", func(t *testing.T) {
+		var logged []string
+		loader := newLoader(newMockSSMClient(map[string]string{}),
+			WithConfigFS(fsys), WithConfigFileBase("config/app"), WithEnvironment("prod"),
+			WithConfigBytes("json", []byte(`{"port": 6543}`)),
+			WithWarnOnFileConflict(true), newLogger(&logged))
+
+		values := loader.loadFromFiles(context.Background())
+		assert.Equal(t, map[string]string{"host": "prod.example.com", "port": "6543", "database/user": "prod"}, values)
+		assert.Equal(t, []string{
+			`WARNING: Config file config/app.prod.yaml overrides key database/user from file config/app.yaml: "app" -> "prod"`,
+			`WARNING: Config file config/app.prod.yaml overrides key host from file config/app.yaml: ` +
+				`"base.example.com" -> "prod.example.com"`,
+			`WARNING: Config document #1 (json) overrides key port from file config/app.prod.yaml: "5432" -> "6543"`,
+		}, logged)
+	})
+
+	t.Run("stays silent when disabled", func(t *testing.T) {
+		var logged []string
+		loader := newLoader(newMockSSMClient(map[string]string{}),
+			WithConfigFS(fsys), WithConfigFileBase("config/app"), WithEnvironment("prod"), newLogger(&logged))
+
+		loader.loadFromFiles(context.Background())
+		assert.Empty(t, logged)
+	})
+}
+
+func TestWithRawFile(t *testing.T) {
+	const cert = "-----BEGIN CERTIFICATE-----\nMIIBszCCAVmgAwIBAgIU\nAbCdEf==\n-----END CERTIFICATE-----\n"
+
+	type Config struct {
+		Host string `ssm:"host"`
+		TLS  struct {
+			Cert string `ssm:"cert"`
+		} `ssm:"tls"`
+	}
+
+	t.Run("injects the whole file under the key", func(t *testing.T) {
+		certFile := filepath.Join(t.TempDir(), "cert.pem")
+		require.NoError(t, os.WriteFile(certFile, []byte(cert), 0o600))
+
+		client := newMockSSMClient(map[string]string{"/myapp/host": "db.example.com", "/myapp/tls/cert": "from-ssm"})
+		loader := newLoader(client, WithRawFile("/tls/cert", certFile))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "db.example.com", cfg.Host)
+		assert.Equal(t, cert, cfg.TLS.Cert)
+	})
+
+	t.Run("overrides structured config files and reads from the config filesystem", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"config.yaml":    {Data: []byte("host: localhost\ntls:\n  cert: inline\n")},
+			"certs/cert.pem": {Data: []byte(cert)},
+		}
+		loader := newLoader(newMockSSMClient(map[string]string{}),
+			WithConfigFS(fsys), WithConfigFiles("config.yaml"), WithRawFile("tls/cert", "certs/cert.pem"))
+
+		assert.Equal(t, map[string]string{"host": "localhost", "tls/cert": cert},
+			loader.loadFromFiles(context.Background()))
+	})
+
+	t.Run("unreadable file is logged and skipped", func(t *testing.T) {
+		var logged []string
+		loader := newLoader(newMockSSMClient(map[string]string{}),
+			WithConfigFS(fstest.MapFS{}), WithRawFile("tls/cert", "missing.pem"),
+			WithLogger(func(format string, args ...interface{}) {
+				logged = append(logged, fmt.Sprintf(format, args...))
+			}))
+
+		assert.Empty(t, loader.loadFromFiles(context.Background()))
+		require.Len(t, logged, 1)
+		assert.Contains(t, logged[0], "WARNING: Failed to read raw file missing.pem for key tls/cert")
+	})
+}
@@ -0,0 +1,34 @@
+package ssmconfig
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// fileEnvSuffix names the variable holding a value's file path under the _FILE convention
+// (DB_PASSWORD_FILE for DB_PASSWORD).
+const fileEnvSuffix = "_FILE"
+
+// isFromFileTag reports whether a fromfile tag marks the field's value as a file path.
+func isFromFileTag(tag string) bool {
+	return tag == jsonTagTrue || tag == jsonTagOne || tag == jsonTagYes
+}
+
+// readValueFile returns the contents of the file at path, read from fsys if set or from disk otherwise.
+// fs.FS paths can't be rooted, so a leading slash is dropped (/run/secrets/db reads run/secrets/db).
+// Trailing newlines, which editors and `echo` usually add to secret files, are removed.
+func readValueFile(fsys fs.FS, path, fieldName string) (string, error) {
+	var data []byte
+	var err error
+	if fsys != nil {
+		data, err = fs.ReadFile(fsys, strings.TrimPrefix(path, "/"))
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading file '%s' for field %s: %w", path, fieldName, err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
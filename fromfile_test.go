@@ -0,0 +1,160 @@
+package ssmconfig
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_FromFileTag(t *testing.T) {
+	type Config struct {
+		Password string `ssm:"db/password_file" env:"TEST_FROMFILE_PASSWORD" fromfile:"true"`
+		Name     string `ssm:"name"`
+	}
+
+	t.Run("reads the value from the path stored in SSM", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "password")
+		require.NoError(t, os.WriteFile(path, []byte("s3cret\n"), 0o600))
+
+		client := newMockSSMClient(map[string]string{
+			"/test/db/password_file": path,
+			"/test/name":             "app",
+		})
+
+		cfg, err := LoadWithLoader[Config](newLoader(client), context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "s3cret", cfg.Password)
+		assert.Equal(t, "app", cfg.Name)
+	})
+
+	t.Run("reads the value from the path in an env override", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "password")
+		require.NoError(t, os.WriteFile(path, []byte("from-env-file"), 0o600))
+		t.Setenv("TEST_FROMFILE_PASSWORD", path)
+
+		client := newMockSSMClient(map[string]string{"/test/db/password_file": "/does/not/exist"})
+
+		cfg, err := LoadWithLoader[Config](newLoader(client), context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "from-env-file", cfg.Password)
+	})
+
+	t.Run("reads from the configured filesystem", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"run/secrets/db_password": {Data: []byte("from-fs\n")},
+		}
+		client := newMockSSMClient(map[string]string{"/test/db/password_file": "/run/secrets/db_password"})
+
+		cfg, err := LoadWithLoader[Config](newLoader(client, WithConfigFS(fsys)), context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "from-fs", cfg.Password)
+	})
+
+	t.Run("decodes JSON nested structs from the file", func(t *testing.T) {
+		type Database struct {
+			Host string `json:"host"`
+			Port int    `json:"port"`
+		}
+		type JSONConfig struct {
+			Database Database `ssm:"database_file" json:"true" fromfile:"true"`
+		}
+
+		fsys := fstest.MapFS{
+			"secrets/database.json": {Data: []byte(`{"host":"db.example.com","port":5432}`)},
+		}
+		client := newMockSSMClient(map[string]string{"/test/database_file": "secrets/database.json"})
+
+		cfg, err := LoadWithLoader[JSONConfig](newLoader(client, WithConfigFS(fsys)), context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, Database{Host: "db.example.com", Port: 5432}, cfg.Database)
+	})
+
+	t.Run("errors name the field and path", func(t *testing.T) {
+		missing := filepath.Join(t.TempDir(), "missing")
+		client := newMockSSMClient(map[string]string{"/test/db/password_file": missing})
+
+		_, err := LoadWithLoader[Config](newLoader(client), context.Background(), "/test/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reading file '"+missing+"' for field Password")
+	})
+
+	t.Run("empty file counts as a missing required value", func(t *testing.T) {
+		type RequiredConfig struct {
+			Password string `ssm:"db/password_file" fromfile:"true" required:"true"`
+		}
+
+		fsys := fstest.MapFS{"secrets/empty": {Data: []byte("\n")}}
+		client := newMockSSMClient(map[string]string{"/test/db/password_file": "secrets/empty"})
+
+		_, err := LoadWithLoader[RequiredConfig](newLoader(client, WithConfigFS(fsys), WithStrictMode(true)),
+			context.Background(), "/test/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field 'Password'")
+	})
+}
+
+func TestWithFileEnvConvention(t *testing.T) {
+	type Config struct {
+		Password string `ssm:"db/password" env:"TEST_FILEENV_PASSWORD"`
+	}
+	client := newMockSSMClient(map[string]string{"/test/db/password": "from-ssm"})
+
+	writeSecret := func(t *testing.T, contents string) string {
+		path := filepath.Join(t.TempDir(), "password")
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+		return path
+	}
+
+	t.Run("reads the value from FOO_FILE", func(t *testing.T) {
+		t.Setenv("TEST_FILEENV_PASSWORD_FILE", writeSecret(t, "from-file\n"))
+
+		cfg, err := LoadWithLoader[Config](newLoader(client, WithFileEnvConvention(true)), context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "from-file", cfg.Password)
+	})
+
+	t.Run("FOO set directly wins over FOO_FILE", func(t *testing.T) {
+		t.Setenv("TEST_FILEENV_PASSWORD", "from-env")
+		t.Setenv("TEST_FILEENV_PASSWORD_FILE", writeSecret(t, "from-file"))
+
+		cfg, err := LoadWithLoader[Config](newLoader(client, WithFileEnvConvention(true)), context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "from-env", cfg.Password)
+	})
+
+	t.Run("FOO_FILE is ignored when disabled", func(t *testing.T) {
+		t.Setenv("TEST_FILEENV_PASSWORD_FILE", writeSecret(t, "from-file"))
+
+		cfg, err := LoadWithLoader[Config](newLoader(client), context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "from-ssm", cfg.Password)
+	})
+
+	t.Run("required nested struct satisfied by FOO_FILE", func(t *testing.T) {
+		type NestedConfig struct {
+			Database struct {
+				Password string `ssm:"password" env:"TEST_FILEENV_PASSWORD"`
+			} `ssm:"database" required:"true"`
+		}
+		t.Setenv("TEST_FILEENV_PASSWORD_FILE", writeSecret(t, "from-file"))
+
+		loader := newLoader(newMockSSMClient(map[string]string{}), WithFileEnvConvention(true), WithStrictMode(true))
+		cfg, err := LoadWithLoader[NestedConfig](loader, context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "from-file", cfg.Database.Password)
+	})
+
+	t.Run("unreadable FOO_FILE names the field and path", func(t *testing.T) {
+		missing := filepath.Join(t.TempDir(), "missing")
+		t.Setenv("TEST_FILEENV_PASSWORD_FILE", missing)
+
+		_, err := LoadWithLoader[Config](newLoader(client, WithFileEnvConvention(true)), context.Background(), "/test/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reading file '"+missing+"' for field Password")
+	})
+}
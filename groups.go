@@ -0,0 +1,32 @@
+package ssmconfig
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// inGroups reports whether a field is mapped with the groups selected by WithGroups. Without
+// selected groups every field is. A field tagged with groups (group:"startup,db") is mapped if
+// any of them is selected; an untagged field is mapped unless WithStrictGroups is set, in which
+// case only if grouped, i.e. an enclosing struct was selected by its own group tag.
+func (m *mapper) inGroups(field reflect.StructField, grouped bool) bool {
+	if len(m.opts.groups) == 0 {
+		return true
+	}
+	tag := field.Tag.Get("group")
+	if tag == "" {
+		return grouped || !m.opts.strictGroups
+	}
+	for _, group := range strings.Split(tag, ",") {
+		if slices.Contains(m.opts.groups, strings.TrimSpace(group)) {
+			return true
+		}
+	}
+	return false
+}
+
+// groupedBy reports whether the fields inside a struct field are grouped (see inGroups).
+func groupedBy(field reflect.StructField, grouped bool) bool {
+	return grouped || field.Tag.Get("group") != ""
+}
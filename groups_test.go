@@ -0,0 +1,97 @@
+package ssmconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithGroups(t *testing.T) {
+	type Cache struct {
+		Host string `ssm:"host" required:"true"`
+		TTL  int    `ssm:"ttl"`
+	}
+	type Config struct {
+		Port     int    `ssm:"port" group:"startup"`
+		LogLevel string `ssm:"log_level" group:"startup,logging"`
+		Region   string `ssm:"region"`
+		// Not yet provisioned while starting up, and would fail validation
+		ReportURL string `ssm:"report_url" group:"reports" required:"true" validate:"url"`
+		Cache     Cache  `ssm:"cache" group:"cache"`
+	}
+	client := newMockSSMClient(map[string]string{
+		"/myapp/port":      "8080",
+		"/myapp/log_level": "debug",
+		"/myapp/region":    "eu-west-1",
+		"/myapp/cache/ttl": "60",
+	})
+	load := func(opts ...LoaderOption) (*Config, error) {
+		loader := newLoader(client, append([]LoaderOption{WithStrictMode(true)}, opts...)...)
+		return LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+	}
+
+	t.Run("maps only the selected groups and ungrouped fields", func(t *testing.T) {
+		cfg, err := load(WithGroups("startup"))
+		require.NoError(t, err)
+		assert.Equal(t, &Config{Port: 8080, LogLevel: "debug", Region: "eu-west-1"}, cfg)
+	})
+
+	t.Run("a field in any selected group is mapped", func(t *testing.T) {
+		cfg, err := load(WithGroups("logging"))
+		require.NoError(t, err)
+		assert.Equal(t, &Config{LogLevel: "debug", Region: "eu-west-1"}, cfg)
+	})
+
+	t.Run("strict groups skip ungrouped fields", func(t *testing.T) {
+		cfg, err := load(WithGroups("startup"), WithStrictGroups(true))
+		require.NoError(t, err)
+		assert.Equal(t, &Config{Port: 8080, LogLevel: "debug"}, cfg)
+	})
+
+	t.Run("a selected struct brings in its ungrouped fields", func(t *testing.T) {
+		_, err := load(WithGroups("cache"), WithStrictGroups(true))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field 'Host' (ssm:'host', env:'')")
+
+		client := newMockSSMClient(map[string]string{"/myapp/cache/host": "redis", "/myapp/cache/ttl": "60"})
+		loader := newLoader(client, WithGroups("cache"), WithStrictGroups(true))
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, &Config{Cache: Cache{Host: "redis", TTL: 60}}, cfg)
+	})
+
+	t.Run("excluded required fields and validators are skipped, not reported", func(t *testing.T) {
+		_, err := load(WithGroups("startup", "logging"))
+		require.NoError(t, err)
+
+		_, err = load(WithGroups("reports"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field 'ReportURL'")
+	})
+
+	t.Run("every field is mapped without selected groups", func(t *testing.T) {
+		// The nested struct reports its missing field first
+		_, err := load()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field 'Host'")
+	})
+
+	t.Run("listed nested required fields respect groups", func(t *testing.T) {
+		type Database struct {
+			Host     string `ssm:"host" required:"true"`
+			Replicas string `ssm:"replicas" required:"true" group:"replication"`
+		}
+		type DBConfig struct {
+			Database Database `ssm:"database" required:"true"`
+		}
+		loader := newLoader(newMockSSMClient(nil),
+			WithStrictMode(true), WithListNestedRequired(true), WithGroups("startup"))
+
+		_, err := LoadWithLoader[DBConfig](loader, context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field 'Database.Host'")
+		assert.NotContains(t, err.Error(), "Replicas")
+	})
+}
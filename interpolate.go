@@ -0,0 +1,135 @@
+package ssmconfig
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// interpolationPattern matches ${NAME} references. A bare $NAME is left alone so values
+// that happen to contain '$' (passwords, connection strings) aren't altered.
+var interpolationPattern = regexp.MustCompile(`\$\{([^{}]*)\}`)
+
+// interpolateEnv expands ${ENV_VAR} references in value from the environment.
+// Undefined variables are left as written, or reported as an error if strict is true.
+func interpolateEnv(value, fieldName string, strict bool) (string, error) {
+	if !strings.Contains(value, "${") {
+		return value, nil
+	}
+
+	var undefined []string
+	expanded := interpolationPattern.ReplaceAllStringFunc(value, func(match string) string {
+		name := match[2 : len(match)-1]
+		if envValue, ok := os.LookupEnv(name); ok {
+			return envValue
+		}
+		undefined = append(undefined, name)
+		return match
+	})
+
+	if strict && len(undefined) > 0 {
+		return "", fmt.Errorf("undefined variable(s) %s in field '%s'", strings.Join(undefined, ", "), fieldName)
+	}
+	return expanded, nil
+}
+
+// maxReferenceDepth is the longest chain of ${ref:key} references that is followed,
+// e.g. a -> b -> c is a chain of depth 2.
+const maxReferenceDepth = 10
+
+// referencePattern matches ${ref:key} references to other loaded keys.
+var referencePattern = regexp.MustCompile(`\$\{ref:([^{}]*)\}`)
+
+// referenceResolver substitutes ${ref:key} references with the values of other keys.
+type referenceResolver struct {
+	values   map[string]string
+	resolved map[string]string
+	depths   map[string]int // Length of the longest reference chain below each resolved key
+	strict   bool
+}
+
+// resolveReferences returns a copy of values with ${ref:key} references replaced by the
+// (recursively resolved) value of key. Keys are relative to the prefix, as in ssm tags.
+// Cycles and chains deeper than maxReferenceDepth are errors. References to missing keys
+// are left as written, or reported as an error if strict is true.
+func resolveReferences(values map[string]string, strict bool) (map[string]string, error) {
+	r := &referenceResolver{
+		values:   values,
+		resolved: make(map[string]string, len(values)),
+		depths:   make(map[string]int, len(values)),
+		strict:   strict,
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys) // Deterministic error reporting
+
+	result := make(map[string]string, len(values))
+	for _, key := range keys {
+		value, err := r.resolve(key, nil)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// resolve returns the value of key with its references substituted. chain holds the
+// keys whose resolution led here and is used to detect cycles and limit depth.
+func (r *referenceResolver) resolve(key string, chain []string) (string, error) {
+	for _, seen := range chain {
+		if seen == key {
+			return "", fmt.Errorf("reference cycle: %s -> %s", strings.Join(chain, " -> "), key)
+		}
+	}
+	if value, ok := r.resolved[key]; ok {
+		if len(chain)+r.depths[key] > maxReferenceDepth {
+			return "", fmt.Errorf("reference depth exceeds %d at key '%s'", maxReferenceDepth, key)
+		}
+		return value, nil
+	}
+	if len(chain) > maxReferenceDepth {
+		return "", fmt.Errorf("reference depth exceeds %d at key '%s'", maxReferenceDepth, key)
+	}
+
+	value := r.values[key]
+	if !strings.Contains(value, "${ref:") {
+		r.resolved[key] = value
+		return value, nil
+	}
+
+	chain = append(append([]string(nil), chain...), key)
+	depth := 0
+	var resolveErr error
+	expanded := referencePattern.ReplaceAllStringFunc(value, func(match string) string {
+		if resolveErr != nil {
+			return match
+		}
+		ref := referencePattern.FindStringSubmatch(match)[1]
+		if _, ok := r.values[ref]; !ok {
+			if r.strict {
+				resolveErr = fmt.Errorf("undefined reference '%s' in key '%s'", ref, key)
+			}
+			return match
+		}
+		refValue, err := r.resolve(ref, chain)
+		if err != nil {
+			resolveErr = err
+			return match
+		}
+		depth = max(depth, r.depths[ref]+1)
+		return refValue
+	})
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+
+	r.resolved[key] = expanded
+	r.depths[key] = depth
+	return expanded, nil
+}
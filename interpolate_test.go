@@ -0,0 +1,128 @@
+package ssmconfig
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapToStruct_Interpolation(t *testing.T) {
+	type Database struct {
+		Host string `json:"host"`
+	}
+
+	type Config struct {
+		URL      string   `ssm:"url"`
+		Database Database `ssm:"database" json:"true"`
+		Password string   `ssm:"password"`
+	}
+
+	t.Setenv("TEST_INTERP_HOST", "db.internal")
+
+	t.Run("expands braced environment references", func(t *testing.T) {
+		values := map[string]string{
+			"url":      "postgres://${TEST_INTERP_HOST}:5432",
+			"database": `{"host":"${TEST_INTERP_HOST}"}`,
+			"password": "pa$word",
+		}
+
+		var result Config
+		err := mapToStructWithOptions(values, &result, &mapOptions{useStrongTyping: true, interpolate: true})
+		require.NoError(t, err)
+		assert.Equal(t, "postgres://db.internal:5432", result.URL)
+		assert.Equal(t, "db.internal", result.Database.Host)
+		assert.Equal(t, "pa$word", result.Password)
+	})
+
+	t.Run("leaves values untouched when disabled", func(t *testing.T) {
+		var result Config
+		err := mapToStruct(map[string]string{"url": "${TEST_INTERP_HOST}"}, &result, false, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, "${TEST_INTERP_HOST}", result.URL)
+	})
+
+	t.Run("leaves undefined references literal", func(t *testing.T) {
+		var result Config
+		err := mapToStructWithOptions(map[string]string{"url": "${TEST_INTERP_UNDEFINED}/x"}, &result,
+			&mapOptions{useStrongTyping: true, interpolate: true})
+		require.NoError(t, err)
+		assert.Equal(t, "${TEST_INTERP_UNDEFINED}/x", result.URL)
+	})
+
+	t.Run("rejects undefined references in strict mode", func(t *testing.T) {
+		var result Config
+		err := mapToStructWithOptions(map[string]string{"url": "${TEST_INTERP_UNDEFINED}"}, &result,
+			&mapOptions{useStrongTyping: true, interpolate: true, strictInterp: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "undefined variable(s) TEST_INTERP_UNDEFINED in field 'URL'")
+	})
+}
+
+func TestResolveReferences(t *testing.T) {
+	t.Run("substitutes nested references", func(t *testing.T) {
+		values := map[string]string{
+			"database/host": "db.internal",
+			"database/addr": "${ref:database/host}:5432",
+			"url":           "postgres://${ref:database/addr}/app",
+		}
+
+		resolved, err := resolveReferences(values, false)
+		require.NoError(t, err)
+		assert.Equal(t, "db.internal:5432", resolved["database/addr"])
+		assert.Equal(t, "postgres://db.internal:5432/app", resolved["url"])
+	})
+
+	t.Run("detects cycles", func(t *testing.T) {
+		values := map[string]string{
+			"a": "${ref:b}",
+			"b": "${ref:a}",
+		}
+
+		_, err := resolveReferences(values, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reference cycle: a -> b -> a")
+	})
+
+	t.Run("limits reference depth", func(t *testing.T) {
+		values := map[string]string{"key0": "end"}
+		for i := 1; i <= maxReferenceDepth+1; i++ {
+			values[fmt.Sprintf("key%d", i)] = fmt.Sprintf("${ref:key%d}", i-1)
+		}
+
+		_, err := resolveReferences(values, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reference depth exceeds")
+	})
+
+	t.Run("leaves missing references literal unless strict", func(t *testing.T) {
+		values := map[string]string{"url": "${ref:missing}"}
+
+		resolved, err := resolveReferences(values, false)
+		require.NoError(t, err)
+		assert.Equal(t, "${ref:missing}", resolved["url"])
+
+		_, err = resolveReferences(values, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "undefined reference 'missing' in key 'url'")
+	})
+
+	t.Run("resolves references when loading", func(t *testing.T) {
+		type Config struct {
+			Host string `ssm:"database/host"`
+			URL  string `ssm:"url"`
+		}
+
+		client := newMockSSMClient(map[string]string{
+			"/test/database/host": "db.internal",
+			"/test/url":           "postgres://${ref:database/host}:5432",
+		})
+		loader := newLoader(client, WithInterpolation(true))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "postgres://db.internal:5432", cfg.URL)
+	})
+}
@@ -0,0 +1,52 @@
+package ssmconfig
+
+import (
+	"strings"
+	"unicode"
+)
+
+// KeyStrategy derives an SSM key from a Go field name for fields without an ssm tag.
+type KeyStrategy string
+
+const (
+	// KeyStrategyNone skips fields without an ssm or env tag (the default).
+	KeyStrategyNone KeyStrategy = ""
+	// KeyStrategySnakeCase maps DatabaseURL to "database_url".
+	KeyStrategySnakeCase KeyStrategy = "snake_case"
+	// KeyStrategyKebabCase maps DatabaseURL to "database-url".
+	KeyStrategyKebabCase KeyStrategy = "kebab-case"
+	// KeyStrategyAsIs uses the field name unchanged.
+	KeyStrategyAsIs KeyStrategy = "as-is"
+)
+
+// key returns the SSM key for the field name, or "" for KeyStrategyNone.
+func (s KeyStrategy) key(fieldName string) string {
+	switch s {
+	case KeyStrategySnakeCase:
+		return splitFieldName(fieldName, '_')
+	case KeyStrategyKebabCase:
+		return splitFieldName(fieldName, '-')
+	case KeyStrategyAsIs:
+		return fieldName
+	default:
+		return ""
+	}
+}
+
+// splitFieldName lowercases a CamelCase name and joins its words with sep.
+// Runs of capitals are kept together as one word, so "HTTPPort" becomes "http_port".
+func splitFieldName(name string, sep rune) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteRune(sep)
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,89 @@
+package ssmconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy KeyStrategy
+		field    string
+		expected string
+	}{
+		{"snake simple", KeyStrategySnakeCase, "Port", "port"},
+		{"snake two words", KeyStrategySnakeCase, "MaxConnections", "max_connections"},
+		{"snake trailing acronym", KeyStrategySnakeCase, "DatabaseURL", "database_url"},
+		{"snake leading acronym", KeyStrategySnakeCase, "HTTPPort", "http_port"},
+		{"snake acronym in middle", KeyStrategySnakeCase, "UseTLSCert", "use_tls_cert"},
+		{"snake digits", KeyStrategySnakeCase, "Retry2Count", "retry2_count"},
+		{"snake all caps", KeyStrategySnakeCase, "ID", "id"},
+		{"kebab", KeyStrategyKebabCase, "APIKeyName", "api-key-name"},
+		{"as-is", KeyStrategyAsIs, "DatabaseURL", "DatabaseURL"},
+		{"none", KeyStrategyNone, "DatabaseURL", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.strategy.key(tt.field))
+		})
+	}
+}
+
+func TestMapToStruct_DefaultKeyStrategy(t *testing.T) {
+	type Database struct {
+		Host    string
+		MaxConn int
+	}
+
+	type Config struct {
+		DatabaseURL string
+		HTTPPort    int
+		Tagged      string `ssm:"custom_key"`
+		Database    Database
+	}
+
+	values := map[string]string{
+		"database_url":      "postgres://localhost",
+		"http_port":         "8080",
+		"custom_key":        "tagged",
+		"database/host":     "db.example.com",
+		"database/max_conn": "10",
+	}
+
+	t.Run("derives snake_case keys for untagged fields", func(t *testing.T) {
+		var result Config
+		err := mapToStructWithOptions(values, &result,
+			&mapOptions{useStrongTyping: true, keyStrategy: KeyStrategySnakeCase})
+		require.NoError(t, err)
+		assert.Equal(t, "postgres://localhost", result.DatabaseURL)
+		assert.Equal(t, 8080, result.HTTPPort)
+		assert.Equal(t, "tagged", result.Tagged)
+		assert.Equal(t, "db.example.com", result.Database.Host)
+		assert.Equal(t, 10, result.Database.MaxConn)
+	})
+
+	t.Run("skips untagged fields by default", func(t *testing.T) {
+		var result Config
+		err := mapToStructWithOptions(values, &result, &mapOptions{useStrongTyping: true})
+		require.NoError(t, err)
+		assert.Empty(t, result.DatabaseURL)
+		assert.Zero(t, result.HTTPPort)
+		assert.Equal(t, "tagged", result.Tagged)
+	})
+
+	t.Run("json tag name takes precedence", func(t *testing.T) {
+		type JSONConfig struct {
+			DatabaseURL string `json:"dbUrl"`
+		}
+
+		var result JSONConfig
+		err := mapToStructWithOptions(map[string]string{"dbUrl": "json", "database_url": "snake"}, &result,
+			&mapOptions{useStrongTyping: true, jsonNameKeys: true, keyStrategy: KeyStrategySnakeCase})
+		require.NoError(t, err)
+		assert.Equal(t, "json", result.DatabaseURL)
+	})
+}
@@ -1,176 +1,1270 @@
 package ssmconfig
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"log"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
+	"unicode"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/spf13/viper"
 )
 
 type cacheEntry struct {
-	values *atomic.Pointer[map[string]string]
-	once   sync.Once
+	values  *atomic.Pointer[map[string]string]
+	once    sync.Once
+	err     error     // Failed load remembered by negative caching; set inside once
+	expires time.Time // If set, the entry is stale from then on (negative caching); set inside once
+}
+
+// expired reports whether a negatively cached entry has outlived its TTL.
+func (e *cacheEntry) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// ErrParameterNotFound is returned by single-key reads when the parameter doesn't exist in SSM.
+var ErrParameterNotFound = errors.New("parameter not found")
+
+// ErrInvalidPrefix is returned when a load prefix isn't a valid SSM parameter path.
+var ErrInvalidPrefix = errors.New("invalid SSM path prefix")
+
+// ErrMissingRequired is wrapped by the error returned when strict mode fails a load because
+// required fields are missing (see MissingRequiredError).
+var ErrMissingRequired = errors.New("missing required fields")
+
+// ErrPartialResults is wrapped by the error returned with a partial configuration when
+// WithPartialResults keeps the parameters fetched before a later page failed.
+var ErrPartialResults = errors.New("partial parameters")
+
+// maxPathLevels is the deepest parameter hierarchy SSM allows.
+const maxPathLevels = 15
+
+// ssmAPI is the subset of the SSM client used by the Loader.
+type ssmAPI interface {
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput,
+		optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+	GetParametersByPath(ctx context.Context, params *ssm.GetParametersByPathInput,
+		optFns ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error)
 }
 
 type Loader struct {
-	ssmClient       *ssm.Client
+	ssmClient       ssmAPI
 	strict          bool
-	logger          func(format string, args ...interface{})
-	cache           sync.Map // map[string]*cacheEntry
-	useStrongTyping bool     // If true, use strongly-typed conversion; if false, prefer JSON decoding
-	configFiles     []string // List of config file paths (YAML, JSON, TOML)
+	logger          func(format string, args ...interface{}) // rawLogger, wrapped by redactor if set
+	rawLogger       func(format string, args ...interface{})
+	redactor        func(msg string) string
+	cache           sync.Map         // map[string]*cacheEntry
+	cacheBackend    CacheBackend     // If set, consulted before SSM on a cache miss
+	cacheBackendTTL time.Duration    // TTL of entries written to cacheBackend
+	cacheEncrypt    cipherFunc       // If set, SecureString values are encrypted in cacheBackend
+	cacheDecrypt    cipherFunc       // Reverses cacheEncrypt when reading cacheBackend
+	cacheNamespace  string           // Prepended to cacheBackend keys, e.g. an account and region
+	backendRefresh  sync.Map         // SSM path -> struct{}; the next fetch skips reading cacheBackend
+	negativeTTL     time.Duration    // If set, empty and failed loads are cached for this long
+	clock           clock            // Source of time for negative caching (the real clock if nil)
+	useStrongTyping bool             // If true, use strongly-typed conversion; if false, prefer JSON decoding
+	configFiles     []string         // List of config file paths (YAML, JSON, TOML)
+	configFileBase  string           // If set, <base>.<ext> and <base>.<environment>.<ext> are loaded
+	environment     string           // Environment whose overlay file is loaded over the base file
+	configFS        fs.FS            // If set, config files are read from this filesystem instead of disk
+	configData      []configDocument // Raw config documents applied after the config files
+	rawFiles        []rawFile        // Files whose whole contents are injected under a key
+	warnOnEmpty     bool             // If true, log a warning when a prefix has no parameters
+	warnOnConflict  bool             // If true, log a warning when a config source overrides an earlier one's value
+	fallbackPrefix  string           // Prefix whose values are used for keys missing under the primary prefix
+	collectErrors   bool             // If true, report all field errors together instead of stopping at the first
+	onFieldError    func(field string, err error)
+	onLoad          func(cfg any, raw map[string]string)
+	lenientBool     bool // If true, accept yes/no, on/off, enabled/disabled for bool fields
+	keyFilter       func(name string) bool
+	pageSize        int32         // MaxResults for GetParametersByPath; 0 uses the API default
+	partialResults  bool          // If true, a failure after the first page keeps the parameters gathered so far
+	requiredKeys    []string      // Parameter keys that must be present before mapping
+	requireOneOf    [][]string    // Groups of field paths where at least one field must be set
+	exclusive       [][]string    // Groups of field paths where at most one field may be set
+	snapshotFile    string        // If set, SSM parameters are read from this file instead of AWS
+	jsonNameKeys    bool          // If true, untagged fields use their json tag name as the SSM key
+	keyStrategy     KeyStrategy   // Derives SSM keys from field names for untagged fields
+	strictJSON      bool          // If true, unknown keys in JSON values are decoding errors
+	lenientJSON     bool          // If true, string fields decoded as JSON accept unquoted values
+	tagName         string        // Struct tag holding the SSM key ("ssm" if empty)
+	strictPanic     bool          // If true, strict-mode failures panic instead of being returned as errors
+	loadTimeout     time.Duration // If set, bounds the total time of a single load
+	interpolate     bool          // If true, ${ENV_VAR} references in values are expanded
+	strictInterp    bool          // If true, undefined ${ENV_VAR} references are errors
+	fileEnv         bool          // If true, FOO_FILE names a file holding the value of env var FOO
+	listNested      bool          // If true, a missing required nested struct also lists its required fields
+	foldCase        bool          // If true, SSM keys are lowercased and matched case-insensitively
+	groups          []string      // If set, only fields in these groups (and ungrouped ones) are mapped
+	unsetSentinel   string        // If set, a value equal to it leaves the field unset
+	strictGroups    bool          // If true, ungrouped fields are skipped when groups are selected
+	tracer          Tracer        // If set, SSM and config file loads are wrapped in spans
+	keepPrefix      bool          // If true, SSM keys keep their full path instead of being relative to the prefix
+	vault           *vaultSecret  // If set, this Vault KV secret is merged over the SSM values
+	sharedClient    bool          // If true, NewLoader reuses a process-wide SSM client (see WithSharedClient)
+	noCache         bool          // If true, every load bypasses the in-memory cache
+	mergeStrategy   mergeFunc     // If set, combines keys found both in SSM (or Vault) and a file
+	globalPrefix    string        // If set, prepended to every SSM path and (as an env prefix) env var name
+	recordFile      string        // If set, every SSM response is recorded to this file
+	replayFile      string        // If set, SSM responses are replayed from this recording
+	parameterTypes  sync.Map      // full parameter name -> types.ParameterType, from the last fetch
+	stamps          sync.Map      // SSM path -> parameterStamp, from the last fetch
+	recordMu        sync.Mutex
+	recording       map[string]map[string]string // prefix -> full parameter name -> value
 }
 
 type LoaderOption func(*Loader)
 
-// WithStrictMode enables strict mode where missing required fields will cause a panic.
-func WithStrictMode(strict bool) LoaderOption {
-	return func(l *Loader) {
-		l.strict = strict
+// rawFile is a file whose contents are the value of a single key.
+type rawFile struct {
+	key  string
+	path string
+}
+
+// configDocument is a raw config document of a known format (yaml, yml, json, or toml).
+type configDocument struct {
+	format string
+	data   []byte
+}
+
+// WithStrictMode enables strict mode where missing required fields fail the load.
+// The failure is returned as an error from Load/LoadWithLoader; use WithStrictPanic
+// to let it panic instead.
+func WithStrictMode(strict bool) LoaderOption {
+	return func(l *Loader) {
+		l.strict = strict
+	}
+}
+
+// WithStrictPanic restores the original strict-mode behavior of panicking out of
+// Load/LoadWithLoader instead of returning the failure as an error. The panic value is the
+// same message string as before ("ssmconfig: Missing required fields: ..."). Default is false.
+func WithStrictPanic(enabled bool) LoaderOption {
+	return func(l *Loader) {
+		l.strictPanic = enabled
+	}
+}
+
+// WithLoadTimeout bounds the total time of each load (all SSM pages, file reads, mapping, and
+// validation), independent of any deadline on the caller's context. When the budget is exceeded
+// the load is cancelled and an error wrapping context.DeadlineExceeded is returned.
+func WithLoadTimeout(timeout time.Duration) LoaderOption {
+	return func(l *Loader) {
+		l.loadTimeout = timeout
+	}
+}
+
+// WithLogger sets a custom logger function for logging missing required fields.
+// This allows integration with logging libraries like Sentry, zap, logrus, etc.
+// The logger function receives a format string and variadic arguments.
+func WithLogger(logger func(format string, args ...interface{})) LoaderOption {
+	return func(l *Loader) {
+		l.rawLogger = logger
+		l.setLogger()
+	}
+}
+
+// WithRedactor passes every log message through redact before it reaches the logger, so secrets
+// or sensitive key names can be scrubbed in one place. The message is fully formatted first and
+// the logger receives the redacted text as its only argument. Only messages from this package
+// are affected; errors returned to the caller are not redacted.
+func WithRedactor(redact func(msg string) string) LoaderOption {
+	return func(l *Loader) {
+		l.redactor = redact
+		l.setLogger()
+	}
+}
+
+// setLogger sets the logger used for messages: the configured logger, wrapped by the redactor
+// if there is one. Keeping both lets WithLogger and WithRedactor be given in any order.
+func (l *Loader) setLogger() {
+	logger, redact := l.rawLogger, l.redactor
+	if logger == nil || redact == nil {
+		l.logger = logger
+		return
+	}
+	l.logger = func(format string, args ...interface{}) {
+		logger("%s", redact(fmt.Sprintf(format, args...)))
+	}
+}
+
+// WithDefaultLogger logs through the standard library's default logger (log.Printf),
+// prefixing each message with "ssmconfig: ". A later WithLogger replaces it.
+func WithDefaultLogger() LoaderOption {
+	return WithLogger(defaultLogger)
+}
+
+// defaultLogger is the logger installed by WithDefaultLogger.
+func defaultLogger(format string, args ...interface{}) {
+	log.Printf("ssmconfig: "+format, args...)
+}
+
+// WithStrongTyping controls whether to use strongly-typed conversion or prefer JSON decoding.
+// If true (default), uses strongly-typed conversion for simple types (int, string, bool, etc.).
+// If false, prefers JSON decoding for all types. The json:"true" tag on fields always takes precedence.
+func WithStrongTyping(useStrongTyping bool) LoaderOption {
+	return func(l *Loader) {
+		l.useStrongTyping = useStrongTyping
+	}
+}
+
+// WithConfigFiles adds configuration file paths to load from.
+// Files are loaded using Viper in order, with later files overriding earlier ones.
+// Supported formats: .yaml, .yml, .json, .toml
+// Priority: ENV > File > SSM
+func WithConfigFiles(filePaths ...string) LoaderOption {
+	return func(l *Loader) {
+		l.configFiles = append(l.configFiles, filePaths...)
+	}
+}
+
+// WithConfigFileBase loads config files by convention from a base path without extension
+// (e.g. "config" or "conf/app"): <base>.yaml, .yml, .json, and .toml are loaded if present,
+// followed by the overlay <base>.<environment>.<ext> when WithEnvironment is set.
+// These files are loaded before the ones given to WithConfigFiles. Missing files are skipped.
+func WithConfigFileBase(base string) LoaderOption {
+	return func(l *Loader) {
+		l.configFileBase = base
+	}
+}
+
+// WithEnvironment sets the environment (e.g. "prod") whose overlay file, such as
+// config.prod.yaml for WithConfigFileBase("config"), overrides the base config file.
+func WithEnvironment(env string) LoaderOption {
+	return func(l *Loader) {
+		l.environment = env
+	}
+}
+
+// WithConfigFS reads the files given to WithConfigFiles from fsys instead of the local disk,
+// e.g. default config embedded with go:embed or an fstest.MapFS in tests. fromfile fields and
+// the pathexists, fileexists, and direxists validators use it too.
+// Paths follow io/fs rules: slash-separated and unrooted ("config/app.yaml").
+func WithConfigFS(fsys fs.FS) LoaderOption {
+	return func(l *Loader) {
+		l.configFS = fsys
+	}
+}
+
+// WithConfigBytes adds a raw config document in the given format ("yaml", "yml", "json", or "toml"),
+// e.g. content mounted from a ConfigMap or fetched over HTTP. Documents are flattened like config
+// files and applied after them, in order, so later documents override earlier ones and the files.
+func WithConfigBytes(format string, data []byte) LoaderOption {
+	return func(l *Loader) {
+		l.configData = append(l.configData, configDocument{format: strings.ToLower(format), data: data})
+	}
+}
+
+// WithRawFile injects the entire contents of the file at path as the value of key (e.g.
+// "tls/cert" for a PEM certificate), unlike WithConfigFiles, which parses structured files.
+// The value has file priority: it overrides SSM and the config files, and environment variables
+// override it. The contents are used verbatim, trailing newline included; a base64-encoded file
+// can be decoded with encoding:"base64". The file is read through WithConfigFS if set; a file
+// that can't be read is logged and skipped.
+func WithRawFile(key, path string) LoaderOption {
+	return func(l *Loader) {
+		l.rawFiles = append(l.rawFiles, rawFile{key: strings.Trim(key, "/"), path: path})
+	}
+}
+
+// WithWarnOnEmptyPrefix logs a warning through the configured logger when a prefix
+// returns no parameters from SSM. This helps catch a misspelled or wrong parameter path.
+func WithWarnOnEmptyPrefix(warn bool) LoaderOption {
+	return func(l *Loader) {
+		l.warnOnEmpty = warn
+	}
+}
+
+// WithWarnOnFileConflict logs a warning, with both values, whenever a config file or document
+// overrides a key set to a different value by an earlier one (e.g. an environment overlay
+// repeating a base file key). The later value still wins. Requires a logger. Default is false.
+func WithWarnOnFileConflict(warn bool) LoaderOption {
+	return func(l *Loader) {
+		l.warnOnConflict = warn
+	}
+}
+
+// WithCollectErrors controls whether mapping continues after a field fails to convert or validate.
+// If true, every conversion and validation error is collected and returned together (via errors.Join),
+// so operators see all bad parameters at once. If false (default), mapping stops at the first error.
+func WithCollectErrors(collect bool) LoaderOption {
+	return func(l *Loader) {
+		l.collectErrors = collect
+	}
+}
+
+// WithOnFieldError sets a callback invoked for each field that fails during mapping.
+// The field is the dotted Go field path (e.g. "Database.Port"). It fires as errors occur,
+// which allows emitting a metric per bad parameter, and composes with WithCollectErrors.
+func WithOnFieldError(callback func(field string, err error)) LoaderOption {
+	return func(l *Loader) {
+		l.onFieldError = callback
+	}
+}
+
+// WithOnLoad sets a callback invoked after each successful load (including auto-refresh reloads)
+// with the mapped config (a *T) and the merged raw values by key, e.g. to log a redacted summary or
+// warm caches. The callback is best-effort: a panic in it is recovered and logged, and never
+// fails the load. Environment overrides are applied to the config but aren't in raw.
+func WithOnLoad(callback func(cfg any, raw map[string]string)) LoaderOption {
+	return func(l *Loader) {
+		l.onLoad = callback
+	}
+}
+
+// WithLenientBool enables additional boolean spellings for bool fields.
+// If true, yes/no, on/off, and enabled/disabled (case-insensitive) are accepted in addition
+// to the values understood by strconv.ParseBool. Default is false (strict parsing).
+func WithLenientBool(lenient bool) LoaderOption {
+	return func(l *Loader) {
+		l.lenientBool = lenient
+	}
+}
+
+// WithFallbackPrefix sets a secondary SSM prefix used for keys absent under the primary prefix.
+// This enables a "defaults + overrides" layout, e.g. loading "/myapp/prod/" with
+// WithFallbackPrefix("/myapp/default/"). Each prefix is cached independently.
+func WithFallbackPrefix(prefix string) LoaderOption {
+	return func(l *Loader) {
+		l.fallbackPrefix = prefix
+	}
+}
+
+// maxPageSize is the largest MaxResults accepted by GetParametersByPath.
+const maxPageSize = 10
+
+// WithPageSize sets the number of parameters requested per GetParametersByPath page (MaxResults).
+// The API accepts 1 to 10; other values make the load fail. Default is the API default.
+func WithPageSize(n int) LoaderOption {
+	return func(l *Loader) {
+		l.pageSize = int32(n)
+	}
+}
+
+// WithPartialResults keeps the parameters gathered so far when GetParametersByPath fails after
+// the first page, instead of failing the load. This trades completeness for availability: Load
+// returns a best-effort config, in which some parameters may be missing, together with an error
+// wrapping ErrPartialResults, so callers can proceed with errors.Is. Partial results are never
+// cached, in memory or in a cache backend, and refreshes treat them as a failure. A failure on
+// the first page still fails the load.
+func WithPartialResults(enabled bool) LoaderOption {
+	return func(l *Loader) {
+		l.partialResults = enabled
+	}
+}
+
+// mergeFunc combines the SSM (or Vault) and file values of a key; see WithMergeStrategy.
+type mergeFunc func(key, ssmVal, fileVal string) string
+
+// WithMergeStrategy sets how a key present both in a config file and in SSM (or Vault) is
+// combined, e.g. to union comma-separated lists or deep-merge JSON values. merge receives the
+// key, the SSM (or Vault) value, and the file value, and returns the value to map. Without a
+// strategy the file value wins. Environment variables still override the merged value.
+func WithMergeStrategy(merge func(key, ssmVal, fileVal string) string) LoaderOption {
+	return func(l *Loader) {
+		l.mergeStrategy = merge
+	}
+}
+
+// WithCache controls the loader's in-memory cache of SSM parameters. With WithCache(false)
+// every load calls SSM instead of reusing an earlier load, which suits short-lived
+// processes such as Lambda invocations where a cache only risks serving stale values across
+// warm starts. A backend set with WithCacheBackend is still read. Default is true.
+func WithCache(enabled bool) LoaderOption {
+	return func(l *Loader) {
+		l.noCache = !enabled
+	}
+}
+
+// WithCacheBackend makes the loader read through an external cache (e.g. Redis or memcached)
+// before calling SSM, so several processes share one load. The loader's in-memory cache still
+// sits in front of the backend. Backend errors are logged and the load falls back to SSM.
+// After InvalidateCache (and so Refresh), the next load of the prefix skips the backend, calls
+// SSM, and overwrites the shared entry, so other processes see the fresh values without the
+// entry ever being missing.
+//
+// A prefix holding SecureString parameters isn't written to the backend unless
+// WithCacheBackendEncryption is also given, so secrets are never stored in plaintext. Entries are
+// keyed by SSM path; use WithCacheBackendNamespace when loaders for different AWS accounts or
+// regions share one backend.
+func WithCacheBackend(backend CacheBackend) LoaderOption {
+	return func(l *Loader) {
+		l.cacheBackend = backend
+	}
+}
+
+// WithCacheBackendEncryption passes SecureString values through encrypt before they are written
+// to the cache backend and through decrypt when they are read back (e.g. using KMS or a key from
+// a secrets manager), so the backend never holds them in plaintext. Other values are stored as is.
+// Without it, a prefix holding SecureString parameters isn't written to the backend.
+// An entry holding encrypted values is treated as a cache miss by loaders without the option,
+// and an encryption or decryption failure is logged and the load falls back to SSM.
+func WithCacheBackendEncryption(encrypt, decrypt func(value []byte) ([]byte, error)) LoaderOption {
+	return func(l *Loader) {
+		l.cacheEncrypt = encrypt
+		l.cacheDecrypt = decrypt
+	}
+}
+
+// WithCacheBackendNamespace prefixes the keys the loader uses in the cache backend with
+// namespace, e.g. an AWS account and region ("123456789012/eu-west-1"). SSM paths are only unique
+// within one account and region, so loaders for different ones sharing a backend would otherwise
+// read each other's parameters.
+func WithCacheBackendNamespace(namespace string) LoaderOption {
+	return func(l *Loader) {
+		l.cacheNamespace = namespace
+	}
+}
+
+// WithCacheBackendTTL sets how long parameters written to the cache backend stay valid.
+// Default is 5 minutes; 0 means they don't expire.
+func WithCacheBackendTTL(ttl time.Duration) LoaderOption {
+	return func(l *Loader) {
+		l.cacheBackendTTL = ttl
+	}
+}
+
+// WithNegativeCacheTTL caches empty and failed SSM loads for ttl, so repeated loads of a
+// consistently absent prefix don't keep calling SSM. Within the TTL, an empty prefix yields no
+// values and a failed one returns the remembered error; afterwards the next load calls SSM again
+// and a successful result replaces the negative entry. Canceled or timed-out loads aren't cached.
+// Default is 0: failures aren't cached and empty results are cached like any other.
+func WithNegativeCacheTTL(ttl time.Duration) LoaderOption {
+	return func(l *Loader) {
+		l.negativeTTL = ttl
+	}
+}
+
+// WithKeyFilter drops SSM parameters for which filter returns false before they are cached and mapped.
+// The filter receives the parameter name relative to the prefix (e.g. "database/host"),
+// the same form used in ssm tags. Useful when a shared path holds many parameters but the
+// struct only needs a few.
+func WithKeyFilter(filter func(name string) bool) LoaderOption {
+	return func(l *Loader) {
+		l.keyFilter = filter
+	}
+}
+
+// WithRequiredKeys fails loading before mapping when any of the given keys is missing (or empty)
+// from the merged SSM and file values, independent of struct tags. This is a coarse guardrail
+// against an under-provisioned environment. Keys are relative to the prefix ("database/host")
+// or absolute paths under it ("/myapp/database/host"); the error lists every missing key.
+func WithRequiredKeys(keys ...string) LoaderOption {
+	return func(l *Loader) {
+		l.requiredKeys = append(l.requiredKeys, keys...)
+	}
+}
+
+// WithRequireOneOf requires that at least one of the given fields is set (non-zero) after mapping,
+// e.g. WithRequireOneOf("APIKey", "OAuthToken"). Fields are Go field paths, with dots for nested
+// structs ("Auth.APIKey"). The option can be given several times to add independent groups.
+func WithRequireOneOf(fields ...string) LoaderOption {
+	return func(l *Loader) {
+		l.requireOneOf = append(l.requireOneOf, fields)
+	}
+}
+
+// WithMutuallyExclusive fails loading when more than one of the given fields is set (non-zero)
+// after mapping, e.g. WithMutuallyExclusive("StaticEndpoint", "ServiceDiscovery").
+// Fields use the same paths as WithRequireOneOf; the error lists the conflicting fields.
+// The fields are variadic, like WithRequireOneOf, so a []string group is passed as group...;
+// each call adds one independent group.
+func WithMutuallyExclusive(fields ...string) LoaderOption {
+	return func(l *Loader) {
+		l.exclusive = append(l.exclusive, fields)
+	}
+}
+
+// WithSnapshotFile reads SSM parameters from a snapshot file instead of calling AWS,
+// which is useful for offline development. Snapshot values sit at the SSM priority level,
+// so config files and environment variables still override them.
+// See DumpSnapshot for the file format.
+func WithSnapshotFile(path string) LoaderOption {
+	return func(l *Loader) {
+		l.snapshotFile = path
+	}
+}
+
+// WithRecord records the parameters returned for every prefix loaded from SSM to path,
+// rewriting the file after each fetch. Use the recording with WithReplay to test config
+// loading against real parameter shapes without AWS. See WithReplay for the file format.
+func WithRecord(path string) LoaderOption {
+	return func(l *Loader) {
+		l.recordFile = path
+	}
+}
+
+// WithReplay serves SSM responses from a recording made with WithRecord instead of calling AWS.
+// Loading a prefix that isn't in the recording fails. The recording is a JSON object keyed
+// by normalized prefix, each holding the full parameter names and values returned for it:
+//
+//	{
+//	  "/myapp": {
+//	    "/myapp/database/host": "db.example.com",
+//	    "/myapp/port": "8080"
+//	  }
+//	}
+//
+// Older recordings keyed by the prefix as it was passed (e.g. "/myapp/") still replay.
+func WithReplay(path string) LoaderOption {
+	return func(l *Loader) {
+		l.replayFile = path
+	}
+}
+
+// WithFallbackToJSONName uses the json tag name as the SSM key for fields that have neither
+// an ssm nor an env tag, so structs already annotated for JSON APIs can be loaded as-is.
+// json:"true" (JSON decoding) and json:"-" don't name a key. Default is false.
+func WithFallbackToJSONName(enabled bool) LoaderOption {
+	return func(l *Loader) {
+		l.jsonNameKeys = enabled
+	}
+}
+
+// WithDefaultKeyStrategy derives the SSM key from the field name for fields without ssm and env tags,
+// e.g. WithDefaultKeyStrategy(KeyStrategySnakeCase) loads DatabaseURL from "database_url".
+// A json tag name (WithFallbackToJSONName) takes precedence. Default is KeyStrategyNone,
+// which skips untagged fields.
+func WithDefaultKeyStrategy(strategy KeyStrategy) LoaderOption {
+	return func(l *Loader) {
+		l.keyStrategy = strategy
+	}
+}
+
+// WithTagName reads the SSM key of each field from the given struct tag instead of "ssm",
+// e.g. WithTagName("cfg") for fields tagged cfg:"database/host". Options such as ",squash"
+// work the same way. The env, required, validate, and json tags are unaffected.
+func WithTagName(name string) LoaderOption {
+	return func(l *Loader) {
+		l.tagName = name
+	}
+}
+
+// WithStrictJSON rejects JSON values containing object keys that don't match a field of the
+// destination struct, so a typo in a JSON parameter surfaces as an error instead of being
+// silently ignored. Default is false (unknown keys are ignored, as with json.Unmarshal).
+func WithStrictJSON(strict bool) LoaderOption {
+	return func(l *Loader) {
+		l.strictJSON = strict
+	}
+}
+
+// WithLenientJSONScalars lets string fields decoded as JSON (with WithStrongTyping(false) or a
+// json:"true" tag) take a value that isn't a JSON string as the literal text, so hello maps as
+// "hello" instead of failing. A valid JSON string still wins: "\"hello\"" maps as hello, without
+// the quotes. Default is false.
+func WithLenientJSONScalars(lenient bool) LoaderOption {
+	return func(l *Loader) {
+		l.lenientJSON = lenient
+	}
+}
+
+// WithInterpolation expands ${ENV_VAR} references in resolved values from the environment
+// before conversion, e.g. "postgres://${DB_HOST}:5432". Only the braced form is expanded.
+// SSM and file values may also reference other loaded keys with ${ref:database/host};
+// references are followed up to 10 levels deep and cycles fail the load.
+// Undefined variables are left as written unless WithStrictInterpolation(true) is set.
+func WithInterpolation(enabled bool) LoaderOption {
+	return func(l *Loader) {
+		l.interpolate = enabled
+	}
+}
+
+// WithStrictInterpolation makes references to undefined environment variables or keys an error
+// when interpolation is enabled. Default is false (the reference is left literal).
+func WithStrictInterpolation(strict bool) LoaderOption {
+	return func(l *Loader) {
+		l.strictInterp = strict
+	}
+}
+
+// WithFileEnvConvention enables the Docker secrets _FILE convention: for a field with env tag FOO,
+// if FOO is unset but FOO_FILE is, the value is read from the file at that path (through
+// WithConfigFS if set). FOO itself still takes precedence. Default is false.
+func WithFileEnvConvention(enabled bool) LoaderOption {
+	return func(l *Loader) {
+		l.fileEnv = enabled
+	}
+}
+
+// WithListNestedRequired makes a missing required nested struct also report the required fields
+// inside it (recursively), so the missing-fields error and warnings give operators every key to
+// create in one pass instead of just the struct. Default is false.
+func WithListNestedRequired(enabled bool) LoaderOption {
+	return func(l *Loader) {
+		l.listNested = enabled
+	}
+}
+
+// WithCaseInsensitiveKeys lowercases the names of SSM parameters relative to the prefix (e.g.
+// "Database/Host" becomes "database/host") and matches ssm, gate, inparam, and required keys
+// case-insensitively, so lookups don't depend on how a parameter's name was capitalized.
+// Config file keys are always lowercase. When names differ only by case (e.g. "Database/Host"
+// and "database/host"), a warning is logged and the all-lowercase name wins, or else the first
+// name in sorted order. The prefix itself is still matched case-sensitively by SSM.
+func WithCaseInsensitiveKeys(enabled bool) LoaderOption {
+	return func(l *Loader) {
+		l.foldCase = enabled
+	}
+}
+
+// WithStripPrefix controls whether the prefix is stripped from SSM parameter names to form the
+// keys matched against ssm tags (enabled by default): "/myapp/database/host" loaded with prefix
+// "/myapp/" (or "/myapp", or "myapp") becomes "database/host". Only a whole path is stripped, so
+// "/myapp" never strips the start of "/myapp-staging/key". With stripping disabled, keys are the
+// full names without the leading slash ("myapp/database/host"), for ssm tags written as full
+// paths; WithRequiredKeys then expects keys in that form, and config file keys must match it.
+func WithStripPrefix(strip bool) LoaderOption {
+	return func(l *Loader) {
+		l.keepPrefix = !strip
+	}
+}
+
+// WithGroups maps and validates only the fields in the given groups, named by group tags
+// (group:"startup" or group:"startup,db"), for phased initialization where part of a large
+// config is loaded later. A struct field in a selected group brings in all of its fields.
+// Fields without a group tag are always mapped unless WithStrictGroups is set. Calls add to
+// the selected groups.
+func WithGroups(groups ...string) LoaderOption {
+	return func(l *Loader) {
+		l.groups = append(l.groups, groups...)
+	}
+}
+
+// WithStrictGroups skips fields without a group tag when groups are selected with WithGroups,
+// unless they are inside a struct selected by its group.
+func WithStrictGroups(strict bool) LoaderOption {
+	return func(l *Loader) {
+		l.strictGroups = strict
+	}
+}
+
+// WithUnsetSentinel treats a value equal to sentinel (e.g. "__UNSET__") as an explicit removal:
+// the field keeps its zero value, its default tag is ignored, and a required field is reported
+// missing. The sentinel is matched against the value resolved from the sources in order, so an
+// environment variable set to it clears the field even if SSM holds a value. This gives stores
+// that can't delete a parameter mid-rollout a way to clear one.
+func WithUnsetSentinel(sentinel string) LoaderOption {
+	return func(l *Loader) {
+		l.unsetSentinel = sentinel
+	}
+}
+
+// WithGlobalPrefix namespaces both SSM paths and environment variables, so the same struct can
+// serve several services by changing only the prefix. With WithGlobalPrefix("svc1"), loading
+// "/myapp/" reads the parameters under "/svc1/myapp/" (fallback prefixes and GetString names
+// are namespaced the same way), and env:"DB_HOST" reads SVC1_DB_HOST. The environment prefix is the global prefix
+// in upper case with characters other than letters and digits replaced by underscores.
+// Keys relative to the load prefix, and so nested struct keys, are unchanged.
+func WithGlobalPrefix(prefix string) LoaderOption {
+	return func(l *Loader) {
+		l.globalPrefix = strings.Trim(prefix, "/")
+	}
+}
+
+// WithTracer wraps each SSM prefix load and config file load in a span started by tracer,
+// recording the prefix, the number of pages fetched, and any error. Without a tracer no
+// spans are started.
+func WithTracer(tracer Tracer) LoaderOption {
+	return func(l *Loader) {
+		l.tracer = tracer
+	}
+}
+
+// WithSharedClient makes NewLoader (and Load, LoadMerged) reuse one SSM client per region and
+// credentials within the process instead of loading the default AWS configuration every time,
+// which makes creating short-lived loaders cheap. The region and credentials are taken from
+// the AWS_* environment variables the default configuration reads; loaders created under
+// different values get different clients. It has no effect on NewLoaderFromConfig.
+func WithSharedClient(shared bool) LoaderOption {
+	return func(l *Loader) {
+		l.sharedClient = shared
+	}
+}
+
+// NewLoader creates a Loader whose SSM client is built from the default AWS configuration.
+func NewLoader(ctx context.Context, opts ...LoaderOption) (*Loader, error) {
+	loader := newLoader(nil, opts...)
+
+	var client ssmAPI
+	var err error
+	if loader.sharedClient {
+		client, err = sharedClient(ctx, newDefaultClient)
+	} else {
+		client, err = newDefaultClient(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	loader.ssmClient = client
+	return loader, nil
+}
+
+// newDefaultClient creates an SSM client from the default AWS configuration.
+func newDefaultClient(ctx context.Context) (ssmAPI, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS config: %w", err)
+	}
+	return ssm.NewFromConfig(cfg), nil
+}
+
+// NewLoaderFromConfig creates a Loader whose SSM client is built from an existing aws.Config,
+// e.g. one already set up with custom retryers or tracing middleware, instead of loading
+// the default configuration again as NewLoader does.
+func NewLoaderFromConfig(cfg aws.Config, opts ...LoaderOption) *Loader {
+	return newLoader(ssm.NewFromConfig(cfg), opts...)
+}
+
+// With returns a copy of the loader with the given options applied on top of its current settings.
+// The copy shares the SSM client (and so the AWS configuration) but has its own empty cache,
+// so invalidating one loader never affects the other.
+func (l *Loader) With(opts ...LoaderOption) *Loader {
+	clone := &Loader{
+		ssmClient:       l.ssmClient,
+		strict:          l.strict,
+		logger:          l.logger,
+		rawLogger:       l.rawLogger,
+		redactor:        l.redactor,
+		useStrongTyping: l.useStrongTyping,
+		configFiles:     append([]string(nil), l.configFiles...),
+		configFileBase:  l.configFileBase,
+		environment:     l.environment,
+		configFS:        l.configFS,
+		cacheBackend:    l.cacheBackend,
+		cacheBackendTTL: l.cacheBackendTTL,
+		cacheEncrypt:    l.cacheEncrypt,
+		cacheDecrypt:    l.cacheDecrypt,
+		cacheNamespace:  l.cacheNamespace,
+		negativeTTL:     l.negativeTTL,
+		clock:           l.clock,
+		configData:      append([]configDocument(nil), l.configData...),
+		rawFiles:        append([]rawFile(nil), l.rawFiles...),
+		warnOnEmpty:     l.warnOnEmpty,
+		warnOnConflict:  l.warnOnConflict,
+		fallbackPrefix:  l.fallbackPrefix,
+		collectErrors:   l.collectErrors,
+		onFieldError:    l.onFieldError,
+		onLoad:          l.onLoad,
+		lenientBool:     l.lenientBool,
+		keyFilter:       l.keyFilter,
+		pageSize:        l.pageSize,
+		partialResults:  l.partialResults,
+		requiredKeys:    append([]string(nil), l.requiredKeys...),
+		requireOneOf:    append([][]string(nil), l.requireOneOf...),
+		exclusive:       append([][]string(nil), l.exclusive...),
+		snapshotFile:    l.snapshotFile,
+		jsonNameKeys:    l.jsonNameKeys,
+		keyStrategy:     l.keyStrategy,
+		strictJSON:      l.strictJSON,
+		lenientJSON:     l.lenientJSON,
+		tagName:         l.tagName,
+		strictPanic:     l.strictPanic,
+		loadTimeout:     l.loadTimeout,
+		interpolate:     l.interpolate,
+		strictInterp:    l.strictInterp,
+		fileEnv:         l.fileEnv,
+		listNested:      l.listNested,
+		foldCase:        l.foldCase,
+		groups:          append([]string(nil), l.groups...),
+		strictGroups:    l.strictGroups,
+		unsetSentinel:   l.unsetSentinel,
+		tracer:          l.tracer,
+		keepPrefix:      l.keepPrefix,
+		vault:           l.vault,
+		sharedClient:    l.sharedClient,
+		noCache:         l.noCache,
+		mergeStrategy:   l.mergeStrategy,
+		globalPrefix:    l.globalPrefix,
+		recordFile:      l.recordFile,
+		replayFile:      l.replayFile,
+	}
+
+	for _, opt := range opts {
+		opt(clone)
+	}
+
+	return clone
+}
+
+// newLoader creates a Loader around the given SSM client and applies the options.
+func newLoader(client ssmAPI, opts ...LoaderOption) *Loader {
+	loader := &Loader{
+		ssmClient:       client,
+		strict:          false,
+		logger:          nil,
+		useStrongTyping: true, // Default to strongly-typed conversion
+		cacheBackendTTL: defaultCacheBackendTTL,
+		clock:           realClock{},
+	}
+
+	for _, opt := range opts {
+		opt(loader)
+	}
+
+	return loader
+}
+
+// Load loads configuration from AWS SSM Parameter Store and returns a typed struct.
+// Environment variables (specified via "env" tags) will override SSM parameter values.
+func Load[T any](ctx context.Context, prefix string, opts ...LoaderOption) (*T, error) {
+	loader, err := NewLoader(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadWithLoader[T](loader, ctx, prefix)
+}
+
+// LoadWithLoader loads configuration using an existing Loader instance.
+// The config is returned only if the whole load succeeds; on any error it is nil, never partially
+// mapped. The exception is WithPartialResults: a config mapped from partial parameters is
+// returned with an error wrapping ErrPartialResults.
+// A strict-mode failure (a missing required field with WithStrictMode(true)) is returned as a
+// *MissingRequiredError wrapping ErrMissingRequired, unless WithStrictPanic(true) is set.
+// Other panics, e.g. from a custom decoder or validator, aren't recovered.
+func LoadWithLoader[T any](loader *Loader, ctx context.Context, prefix string) (*T, error) {
+	return LoadMergedWithLoader[T](loader, ctx, []string{prefix})
+}
+
+// LoadMerged loads several SSM prefixes (e.g. "/shared/" and "/myapp/") and maps them into one struct.
+// Prefixes are merged in order, so a key under a later prefix overrides the same key under an
+// earlier one. Config files and environment variables still override all of them.
+// Each prefix is cached independently.
+func LoadMerged[T any](ctx context.Context, prefixes []string, opts ...LoaderOption) (*T, error) {
+	loader, err := NewLoader(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return LoadMergedWithLoader[T](loader, ctx, prefixes)
+}
+
+// LoadMergedWithLoader is LoadMerged using an existing Loader instance.
+func LoadMergedWithLoader[T any](loader *Loader, ctx context.Context, prefixes []string) (*T, error) {
+	return loadMerged[T](loader, ctx, prefixes, loader.strictPanic)
+}
+
+// loadMerged is LoadMergedWithLoader, with strictPanic in place of the loader's WithStrictPanic.
+func loadMerged[T any](loader *Loader, ctx context.Context, prefixes []string,
+	strictPanic bool) (config *T, err error) {
+	if loader.loadTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, loader.loadTimeout)
+		defer cancel()
+
+		config, err = loadWithLoader[T](loader, ctx, prefixes, strictPanic)
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			if err == nil {
+				err = ctx.Err()
+			}
+			return nil, fmt.Errorf("load exceeded timeout of %s: %w", loader.loadTimeout, err)
+		}
+		return config, err
+	}
+
+	return loadWithLoader[T](loader, ctx, prefixes, strictPanic)
+}
+
+// loadWithLoader loads, merges, and maps the configuration values.
+// With strictPanic, a strict-mode failure panics with its message instead of being returned.
+func loadWithLoader[T any](loader *Loader, ctx context.Context, prefixes []string, strictPanic bool) (*T, error) {
+	values, err := loader.loadValues(ctx, prefixes)
+	if err != nil && !errors.Is(err, ErrPartialResults) {
+		return nil, err
+	}
+
+	config, mapErr := mapValues[T](loader, values.values, values.parameterTypes, strictPanic)
+	if mapErr != nil {
+		return nil, mapErr
+	}
+	loader.runOnLoad(config, values.values)
+	return config, err
+}
+
+// runOnLoad invokes the WithOnLoad callback, logging instead of propagating a panic in it.
+func (l *Loader) runOnLoad(config any, raw map[string]string) {
+	if l.onLoad == nil {
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil && l.logger != nil {
+			l.logger("WARNING: OnLoad callback failed: %v", r)
+		}
+	}()
+	l.onLoad(config, raw)
+}
+
+// MapInto maps an already-fetched values map into a new T without calling SSM, e.g. to reuse
+// one fetch for several target structs. Keys are relative to the prefix ("database/host"), as
+// in the values held by RefreshingConfig or the Viper provider. Environment variables, mapping
+// options, ${ref:key} references (with WithInterpolation), and struct-level constraints apply
+// as they do in Load; options that fetch values (config files, SSM settings) have no effect.
+func MapInto[T any](values map[string]string, opts ...LoaderOption) (*T, error) {
+	return mapIntoWithLoader[T](newLoader(nil, opts...), values)
+}
+
+// mapIntoWithLoader is MapInto using the options of an existing Loader.
+func mapIntoWithLoader[T any](loader *Loader, values map[string]string) (*T, error) {
+	if loader.interpolate {
+		resolved, err := resolveReferences(values, loader.strictInterp)
+		if err != nil {
+			return nil, fmt.Errorf("resolving references: %w", err)
+		}
+		values = resolved
+	}
+
+	return mapValues[T](loader, values, nil, loader.strictPanic)
+}
+
+// mapValues maps values into a new T and checks the struct-level constraints. The T is only
+// returned once every field has been converted and validated and the constraints hold, so a
+// failure partway through never exposes the fields mapped before it. With strictPanic, a
+// strict-mode failure panics with its message instead of being returned.
+func mapValues[T any](loader *Loader, values, parameterTypes map[string]string, strictPanic bool) (*T, error) {
+	opts := loader.mapOptions()
+	opts.parameterTypes = parameterTypes
+	opts.strictError = !strictPanic
+
+	var result T
+	if err := mapToStructWithOptions(values, &result, opts); err != nil {
+		// A strict-mode failure keeps the message it has when panicking
+		var missing *MissingRequiredError
+		if errors.As(err, &missing) {
+			return nil, missing
+		}
+		return nil, fmt.Errorf("mapping to struct: %w", err)
+	}
+
+	if err := checkRequireOneOf(&result, loader.requireOneOf); err != nil {
+		return nil, err
+	}
+	if err := checkMutuallyExclusive(&result, loader.exclusive); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// loadedValues holds the merged values of a load along with metadata about each key.
+type loadedValues struct {
+	values         map[string]string // Merged file and SSM values, references resolved
+	parameterTypes map[string]string // SSM types of list parameters (e.g. StringList) by key
+	sources        map[string]string // Source of each value by key (SourceFile or SourceSSM)
+}
+
+// loadValues loads the config files and SSM prefixes and merges them, so that file values
+// override SSM values and later prefixes override earlier ones. Values merged from partial
+// parameters are returned with an error wrapping ErrPartialResults.
+func (l *Loader) loadValues(ctx context.Context, prefixes []string) (*loadedValues, error) {
+	if len(prefixes) == 0 {
+		return nil, fmt.Errorf("no prefixes to load")
+	}
+
+	// Load from config files using Viper (if configured)
+	fileValues := l.loadFromFiles(ctx)
+
+	// Load from SSM Parameter Store (fallback prefix first, so the primary prefix wins).
+	// Later prefixes override earlier ones.
+	ssmValues := make(map[string]string)
+	parameterTypes := make(map[string]string)
+	var partialErr error // Partial parameters are merged, and the error returned with the values
+	for _, prefix := range prefixes {
+		values, err := l.loadSSMValues(ctx, prefix)
+		if err != nil {
+			if !errors.Is(err, ErrPartialResults) {
+				return nil, err
+			}
+			partialErr = errors.Join(partialErr, err)
+		}
+		for k, v := range values {
+			ssmValues[k] = v
+			delete(parameterTypes, k)
+		}
+		for k, typ := range l.listParameterTypes(prefix, values, fileValues) {
+			parameterTypes[k] = typ
+		}
+	}
+
+	// Load the Vault secret (if configured), whose values override SSM's
+	vaultValues, err := l.loadFromVault(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Merge: Start with SSM values, then overlay Vault and file values
+	// File values override Vault and SSM values (but ENV will override all in mapToStruct)
+	mergedValues := make(map[string]string)
+	sources := make(map[string]string)
+	// First add SSM values
+	for k, v := range ssmValues {
+		mergedValues[k] = v
+		sources[k] = string(SourceSSM)
+	}
+	// Then overlay Vault values, which aren't SSM parameters of any list type
+	for k, v := range vaultValues {
+		mergedValues[k] = v
+		sources[k] = string(SourceVault)
+		delete(parameterTypes, k)
+	}
+	// Then overlay file values (file values take precedence over SSM, unless a merge
+	// strategy combines both)
+	for k, v := range fileValues {
+		if existing, ok := mergedValues[k]; ok && l.mergeStrategy != nil {
+			v = l.mergeStrategy(k, existing, v)
+		}
+		mergedValues[k] = v
+		sources[k] = string(SourceFile)
+	}
+
+	// Without prefix stripping, keys are full paths and there is no prefix to strip from required keys
+	var keyPrefixes []string
+	if !l.keepPrefix {
+		for _, prefix := range prefixes {
+			keyPrefixes = append(keyPrefixes, l.ssmPath(prefix))
+		}
+	}
+	if err := checkRequiredKeys(mergedValues, keyPrefixes, l.requiredKeys, l.foldCase); err != nil {
+		return nil, err
 	}
-}
 
-// WithLogger sets a custom logger function for logging missing required fields.
-// This allows integration with logging libraries like Sentry, zap, logrus, etc.
-// The logger function receives a format string and variadic arguments.
-func WithLogger(logger func(format string, args ...interface{})) LoaderOption {
-	return func(l *Loader) {
-		l.logger = logger
+	// Substitute ${ref:key} references between loaded values
+	if l.interpolate {
+		resolved, err := resolveReferences(mergedValues, l.strictInterp)
+		if err != nil {
+			return nil, fmt.Errorf("resolving references: %w", err)
+		}
+		mergedValues = resolved
 	}
+
+	return &loadedValues{values: mergedValues, parameterTypes: parameterTypes, sources: sources}, partialErr
 }
 
-// WithStrongTyping controls whether to use strongly-typed conversion or prefer JSON decoding.
-// If true (default), uses strongly-typed conversion for simple types (int, string, bool, etc.).
-// If false, prefers JSON decoding for all types. The json:"true" tag on fields always takes precedence.
-func WithStrongTyping(useStrongTyping bool) LoaderOption {
-	return func(l *Loader) {
-		l.useStrongTyping = useStrongTyping
+// mapOptions returns the mapping options configured on the loader.
+func (l *Loader) mapOptions() *mapOptions {
+	return &mapOptions{
+		strict:          l.strict,
+		logger:          l.logger,
+		useStrongTyping: l.useStrongTyping,
+		collectErrors:   l.collectErrors,
+		onFieldError:    l.onFieldError,
+		lenientBool:     l.lenientBool,
+		jsonNameKeys:    l.jsonNameKeys,
+		keyStrategy:     l.keyStrategy,
+		strictJSON:      l.strictJSON,
+		lenientJSON:     l.lenientJSON,
+		tagName:         l.tagName,
+		interpolate:     l.interpolate,
+		strictInterp:    l.strictInterp,
+		fsys:            l.configFS,
+		fileEnv:         l.fileEnv,
+		listNested:      l.listNested,
+		foldCase:        l.foldCase,
+		groups:          l.groups,
+		strictGroups:    l.strictGroups,
+		unsetSentinel:   l.unsetSentinel,
+		envPrefix:       envPrefix(l.globalPrefix),
 	}
 }
 
-// WithConfigFiles adds configuration file paths to load from.
-// Files are loaded using Viper in order, with later files overriding earlier ones.
-// Supported formats: .yaml, .yml, .json, .toml
-// Priority: ENV > File > SSM
-func WithConfigFiles(filePaths ...string) LoaderOption {
-	return func(l *Loader) {
-		l.configFiles = append(l.configFiles, filePaths...)
+// loadSSMValues loads the primary prefix and, if configured, the fallback prefix.
+// Values from the primary prefix override those from the fallback prefix.
+// Partial parameters are returned with an error wrapping ErrPartialResults.
+func (l *Loader) loadSSMValues(ctx context.Context, prefix string) (map[string]string, error) {
+	if l.fallbackPrefix == "" || l.fallbackPrefix == prefix {
+		return l.loadByPrefix(ctx, prefix)
 	}
-}
 
-func NewLoader(ctx context.Context, opts ...LoaderOption) (*Loader, error) {
-	cfg, err := config.LoadDefaultConfig(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("loading AWS config: %w", err)
+	fallbackValues, fallbackErr := l.loadByPrefix(ctx, l.fallbackPrefix)
+	if fallbackErr != nil {
+		fallbackErr = fmt.Errorf("loading fallback prefix %s: %w", l.fallbackPrefix, fallbackErr)
+		if !errors.Is(fallbackErr, ErrPartialResults) {
+			return nil, fallbackErr
+		}
 	}
 
-	loader := &Loader{
-		ssmClient:       ssm.NewFromConfig(cfg),
-		strict:          false,
-		logger:          nil,
-		useStrongTyping: true, // Default to strongly-typed conversion
+	primaryValues, err := l.loadByPrefix(ctx, prefix)
+	if err != nil && !errors.Is(err, ErrPartialResults) {
+		return nil, err
 	}
 
-	for _, opt := range opts {
-		opt(loader)
+	for k, v := range primaryValues {
+		fallbackValues[k] = v
 	}
 
-	return loader, nil
+	return fallbackValues, errors.Join(fallbackErr, err)
 }
 
-// Load loads configuration from AWS SSM Parameter Store and returns a typed struct.
-// Environment variables (specified via "env" tags) will override SSM parameter values.
-func Load[T any](ctx context.Context, prefix string, opts ...LoaderOption) (*T, error) {
-	loader, err := NewLoader(ctx, opts...)
-	if err != nil {
-		return nil, err
-	}
+// listParameterTypes returns the SSM types of the StringList values in ssmValues, keyed like the
+// values. Keys overridden by a config file are left out, since the file value is mapped instead.
+func (l *Loader) listParameterTypes(prefix string, ssmValues, fileValues map[string]string) map[string]string {
+	result := make(map[string]string)
+	for key := range ssmValues {
+		if _, overridden := fileValues[key]; overridden {
+			continue
+		}
 
-	return LoadWithLoader[T](loader, ctx, prefix)
+		// The primary prefix wins over the fallback prefix, as for the values themselves
+		typ, ok := l.parameterType(prefix, key)
+		if !ok && l.fallbackPrefix != "" {
+			typ, _ = l.parameterType(l.fallbackPrefix, key)
+		}
+		if typ == types.ParameterTypeStringList {
+			result[key] = string(typ)
+		}
+	}
+	return result
 }
 
-// LoadWithLoader loads configuration using an existing Loader instance.
-func LoadWithLoader[T any](loader *Loader, ctx context.Context, prefix string) (*T, error) {
-	// Load from SSM Parameter Store
-	ssmValues, err := loader.loadByPrefix(ctx, prefix)
-	if err != nil {
-		return nil, err
-	}
+// parameterStamp summarizes the parameters fetched for an SSM path, so an incremental refresh
+// can tell whether any of them changed without mapping the values again.
+type parameterStamp struct {
+	newest time.Time // Latest LastModifiedDate of the parameters; zero if SSM reported none
+	count  int       // Number of parameters, so deletions are noticed too
+}
 
-	// Load from config files using Viper (if configured)
-	fileValues := loader.loadFromFiles()
+// equal reports whether two stamps describe the same parameters.
+func (s parameterStamp) equal(other parameterStamp) bool {
+	return s.newest.Equal(other.newest) && s.count == other.count
+}
 
-	// Merge: Start with SSM values, then overlay file values
-	// File values override SSM values (but ENV will override both in mapToStruct)
-	mergedValues := make(map[string]string)
-	// First add SSM values
-	for k, v := range ssmValues {
-		mergedValues[k] = v
-	}
-	// Then overlay file values (file values take precedence over SSM)
-	for k, v := range fileValues {
-		mergedValues[k] = v
+// parameterStamps returns the stamps of the SSM paths last fetched for prefix and, if set, the
+// fallback prefix. It returns nil if any of them is unknown (e.g. the parameters came from a
+// snapshot) or lacks modification dates.
+func (l *Loader) parameterStamps(prefix string) []parameterStamp {
+	prefixes := []string{prefix}
+	if l.fallbackPrefix != "" && l.fallbackPrefix != prefix {
+		prefixes = append(prefixes, l.fallbackPrefix)
 	}
 
-	var result T
-	if err := mapToStruct(mergedValues, &result, loader.strict, loader.logger, loader.useStrongTyping); err != nil {
-		return nil, fmt.Errorf("mapping to struct: %w", err)
+	stamps := make([]parameterStamp, 0, len(prefixes))
+	for _, p := range prefixes {
+		value, ok := l.stamps.Load(l.ssmPath(p))
+		if !ok {
+			return nil
+		}
+		stamp, ok := value.(parameterStamp)
+		if !ok || (stamp.newest.IsZero() && stamp.count > 0) {
+			return nil
+		}
+		stamps = append(stamps, stamp)
 	}
+	return stamps
+}
 
-	return &result, nil
+// parameterType returns the SSM type recorded for the parameter key under prefix.
+func (l *Loader) parameterType(prefix, key string) (types.ParameterType, bool) {
+	value, ok := l.parameterTypes.Load(l.parameterName(l.ssmPath(prefix), key))
+	if !ok {
+		return "", false
+	}
+	typ, ok := value.(types.ParameterType)
+	return typ, ok
 }
 
 // loadFromFiles loads configuration from YAML, JSON, and TOML files using Viper.
 // Returns a flat map[string]string compatible with SSM parameter format.
-func (l *Loader) loadFromFiles() map[string]string {
-	if len(l.configFiles) == 0 {
+func (l *Loader) loadFromFiles(ctx context.Context) map[string]string {
+	configFiles := l.configFilePaths()
+	if len(configFiles) == 0 && len(l.configData) == 0 && len(l.rawFiles) == 0 {
 		return make(map[string]string)
 	}
 
+	_, span := l.startSpan(ctx, spanLoadFromFiles)
+	defer endSpan(span, nil)
+
 	v := viper.New()
 	firstFile := true
+	read := 0
+	var conflicts *conflictTracker
+	if l.warnOnConflict && l.logger != nil {
+		conflicts = &conflictTracker{seen: make(map[string]configValue), logger: l.logger}
+	}
 
 	// Load each file
-	for _, filePath := range l.configFiles {
+	for _, filePath := range configFiles {
 		if filePath == "" {
 			continue
 		}
 
-		// Check if file exists
-		if _, err := os.Stat(filePath); os.IsNotExist(err) {
+		data, err := l.readConfigFile(filePath)
+		if errors.Is(err, fs.ErrNotExist) {
 			continue // Skip non-existent files
 		}
+		if err != nil {
+			if l.logger != nil {
+				l.logger("WARNING: Failed to read config file %s: %v", filePath, err)
+			}
+			if span != nil {
+				span.RecordError(fmt.Errorf("reading config file %s: %w", filePath, err))
+			}
+			continue
+		}
 
-		// Set file path
-		v.SetConfigFile(filePath)
+		// The format is taken from the file extension
+		format := strings.TrimPrefix(filepath.Ext(filePath), ".")
+		if l.readConfigData(v, format, data, "file "+filePath, firstFile) {
+			firstFile = false
+			read++
+			conflicts.add(format, data, "file "+filePath)
+		}
+	}
 
-		if firstFile {
-			// Read first config file
-			if err := v.ReadInConfig(); err != nil {
-				if l.logger != nil {
-					l.logger("WARNING: Failed to read config file %s: %v", filePath, err)
-				}
-				continue
-			}
+	// Raw config data is applied after the files, in the order it was given
+	for i, source := range l.configData {
+		name := fmt.Sprintf("document #%d (%s)", i+1, source.format)
+		if l.readConfigData(v, source.format, source.data, name, firstFile) {
 			firstFile = false
-		} else {
-			// Merge subsequent files (later files override earlier ones)
-			if err := v.MergeInConfig(); err != nil {
-				if l.logger != nil {
-					l.logger("WARNING: Failed to merge config file %s: %v", filePath, err)
-				}
-				continue
+			read++
+			conflicts.add(source.format, source.data, name)
+		}
+	}
+	result := flattenViper(v)
+
+	// Raw files are applied last, each as the whole value of its key
+	for _, raw := range l.rawFiles {
+		data, err := l.readConfigFile(raw.path)
+		if err != nil {
+			if l.logger != nil {
+				l.logger("WARNING: Failed to read raw file %s for key %s: %v", raw.path, raw.key, err)
 			}
+			if span != nil {
+				span.RecordError(fmt.Errorf("reading raw file %s: %w", raw.path, err))
+			}
+			continue
 		}
+		read++
+		conflicts.set(raw.key, string(data), "raw file "+raw.path)
+		result[raw.key] = string(data)
 	}
+	if span != nil {
+		span.SetAttribute(attrFiles, read)
+	}
+
+	return result
+}
 
-	// Convert Viper's nested config to flat map[string]string
+// flattenViper converts Viper's nested config to a flat map[string]string.
+func flattenViper(v *viper.Viper) map[string]string {
 	// Viper uses dot notation (e.g., "database.host"), which matches our SSM format
 	result := make(map[string]string)
 
@@ -183,16 +1277,172 @@ func (l *Loader) loadFromFiles() map[string]string {
 		// Get value and convert to string
 		value := v.Get(key)
 		if value != nil {
-			// Convert to string representation
-			result[ssmKey] = fmt.Sprintf("%v", value)
+			flattenFileValue(result, ssmKey, value)
 		}
 	}
 
 	return result
 }
 
+// configValue is a config file value along with the source it came from.
+type configValue struct {
+	value  string
+	source string
+}
+
+// conflictTracker remembers the values of the config sources read so far, to warn when a
+// later source overrides a key with a different value.
+type conflictTracker struct {
+	seen   map[string]configValue // key -> last value and its source
+	logger func(format string, args ...interface{})
+}
+
+// add records the values of a config source that was merged, warning for each key it overrides.
+// It does nothing on a nil tracker, so callers needn't check whether warnings are enabled.
+func (c *conflictTracker) add(format string, data []byte, name string) {
+	if c == nil {
+		return
+	}
+
+	v := viper.New()
+	v.SetConfigType(format)
+	if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+		return // Already merged successfully, so this can't normally fail
+	}
+	values := flattenViper(v)
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		c.set(key, values[key], name)
+	}
+}
+
+// set records the value of a key from a config source, warning if it overrides another value.
+func (c *conflictTracker) set(key, value, name string) {
+	if c == nil {
+		return
+	}
+	if previous, ok := c.seen[key]; ok && previous.value != value {
+		c.logger("WARNING: Config %s overrides key %s from %s: %q -> %q",
+			name, key, previous.source, previous.value, value)
+	}
+	c.seen[key] = configValue{value: value, source: name}
+}
+
+// configFileExtensions are the extensions tried for WithConfigFileBase, in load order.
+var configFileExtensions = []string{"yaml", "yml", "json", "toml"}
+
+// configFilePaths returns the config files to load in order: the base and environment overlay
+// files from WithConfigFileBase, then the files given to WithConfigFiles.
+func (l *Loader) configFilePaths() []string {
+	if l.configFileBase == "" {
+		return l.configFiles
+	}
+
+	paths := make([]string, 0, 2*len(configFileExtensions)+len(l.configFiles))
+	for _, ext := range configFileExtensions {
+		paths = append(paths, l.configFileBase+"."+ext)
+	}
+	if l.environment != "" {
+		for _, ext := range configFileExtensions {
+			paths = append(paths, l.configFileBase+"."+l.environment+"."+ext)
+		}
+	}
+	return append(paths, l.configFiles...)
+}
+
+// readConfigData reads data of the given format into v, replacing its config for the first
+// source and merging over it (later sources override earlier ones) otherwise.
+// Failures are logged and reported as false so the source is skipped.
+func (l *Loader) readConfigData(v *viper.Viper, format string, data []byte, name string, first bool) bool {
+	v.SetConfigType(format)
+
+	if first {
+		if err := v.ReadConfig(bytes.NewReader(data)); err != nil {
+			if l.logger != nil {
+				l.logger("WARNING: Failed to read config %s: %v", name, err)
+			}
+			return false
+		}
+		return true
+	}
+
+	if err := v.MergeConfig(bytes.NewReader(data)); err != nil {
+		if l.logger != nil {
+			l.logger("WARNING: Failed to merge config %s: %v", name, err)
+		}
+		return false
+	}
+	return true
+}
+
+// readConfigFile reads a config file from the configured filesystem, or from disk if none is set.
+func (l *Loader) readConfigFile(path string) ([]byte, error) {
+	if l.configFS != nil {
+		return fs.ReadFile(l.configFS, path)
+	}
+	return os.ReadFile(path)
+}
+
+// flattenFileValue converts a value read from a config file into flat string entries.
+// Lists of tables (e.g. TOML [[servers]] or YAML lists of mappings) are stored both as
+// a JSON document under the key (for json-tagged fields) and as indexed keys such as
+// "servers/0/name". Other values use their default string representation.
+func flattenFileValue(result map[string]string, key string, value interface{}) {
+	items, ok := value.([]interface{})
+	if !ok {
+		if tables, isTables := value.([]map[string]interface{}); isTables {
+			items = make([]interface{}, len(tables))
+			for i, table := range tables {
+				items[i] = table
+			}
+			ok = true
+		}
+	}
+	if !ok || !containsTables(items) {
+		result[key] = fmt.Sprintf("%v", value)
+		return
+	}
+
+	if encoded, err := json.Marshal(items); err == nil {
+		result[key] = string(encoded)
+	}
+	for i, item := range items {
+		flattenNestedValue(result, fmt.Sprintf("%s/%d", key, i), item)
+	}
+}
+
+// flattenNestedValue flattens maps and lists of tables found inside a list element.
+func flattenNestedValue(result map[string]string, key string, value interface{}) {
+	switch nested := value.(type) {
+	case map[string]interface{}:
+		for k, v := range nested {
+			flattenNestedValue(result, key+"/"+k, v)
+		}
+	case nil:
+		return
+	default:
+		flattenFileValue(result, key, value)
+	}
+}
+
+// containsTables reports whether any list element is a table (map).
+func containsTables(items []interface{}) bool {
+	for _, item := range items {
+		if _, ok := item.(map[string]interface{}); ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (l *Loader) loadByPrefix(ctx context.Context, prefix string) (map[string]string, error) {
-	return l.loadByPrefixWithCache(ctx, prefix, true)
+	return l.loadByPrefixWithCache(ctx, prefix, !l.noCache)
 }
 
 // loadByPrefixWithCache loads parameters with optional cache bypass.
@@ -203,6 +1453,10 @@ func (l *Loader) loadByPrefixWithCache(ctx context.Context, prefix string, useCa
 	if !useCache {
 		result, err := l.loadFromSSM(ctx, prefix)
 		if err != nil {
+			// Partial parameters are returned but never cached
+			if errors.Is(err, ErrPartialResults) {
+				return result, err
+			}
 			return nil, err
 		}
 
@@ -252,6 +1506,12 @@ func (l *Loader) loadByPrefixWithCache(ctx context.Context, prefix string, useCa
 		}
 	}
 
+	// A negatively cached entry past its TTL is dropped and the load starts over
+	if entry.values.Load() != nil && entry.expired(l.now()) {
+		l.cache.CompareAndDelete(prefix, entry)
+		return l.loadByPrefixWithCache(ctx, prefix, useCache)
+	}
+
 	// Check if already cached
 	cachedValues := entry.values.Load()
 	if cachedValues != nil {
@@ -269,7 +1529,20 @@ func (l *Loader) loadByPrefixWithCache(ctx context.Context, prefix string, useCa
 
 	entry.once.Do(func() {
 		result, loadErr = l.loadFromSSM(ctx, prefix)
-		if loadErr == nil {
+		if loadErr != nil {
+			if l.negativeTTL > 0 && ctx.Err() == nil && !errors.Is(loadErr, ErrPartialResults) {
+				// Remember the failure; later loads return it until the TTL passes
+				entry.err = loadErr
+				entry.expires = l.now().Add(l.negativeTTL)
+				return
+			}
+			// Drop the failed (or partial) entry so the next load retries instead of finding a
+			// spent sync.Once
+			l.cache.CompareAndDelete(prefix, entry)
+		} else {
+			if len(result) == 0 && l.negativeTTL > 0 {
+				entry.expires = l.now().Add(l.negativeTTL)
+			}
 			// Make a copy for the cache
 			cachedValues := make(map[string]string, len(result))
 			for k, v := range result {
@@ -281,7 +1554,17 @@ func (l *Loader) loadByPrefixWithCache(ctx context.Context, prefix string, useCa
 	})
 
 	if loadErr != nil {
-		return nil, loadErr
+		// Partial parameters are returned (uncached) with the error; otherwise result is nil
+		return result, loadErr
+	}
+
+	// Another load of this entry failed and the failure is negatively cached
+	if result == nil && entry.err != nil {
+		if entry.expired(l.now()) {
+			l.cache.CompareAndDelete(prefix, entry)
+			return l.loadByPrefixWithCache(ctx, prefix, useCache)
+		}
+		return nil, entry.err
 	}
 
 	// If we loaded successfully, result is already set
@@ -308,8 +1591,194 @@ func (l *Loader) loadByPrefixWithCache(ctx context.Context, prefix string, useCa
 }
 
 // loadFromSSM performs the actual SSM API call to load parameters.
-func (l *Loader) loadFromSSM(ctx context.Context, prefix string) (map[string]string, error) {
+func (l *Loader) loadFromSSM(ctx context.Context, prefix string) (_ map[string]string, err error) {
+	ctx, span := l.startSpan(ctx, spanLoadFromSSM)
+	if span != nil {
+		span.SetAttribute(attrPrefix, prefix)
+		defer func() { endSpan(span, err) }()
+	}
+
+	path := l.ssmPath(prefix)
+	if err := validatePrefixPath(path); err != nil {
+		return nil, err
+	}
+	params, err := l.fetchParametersCached(ctx, path)
+	if err != nil && !errors.Is(err, ErrPartialResults) {
+		return nil, err
+	}
+
+	out := make(map[string]string)
+	for fullName, value := range params {
+		name := l.parameterKey(path, fullName)
+		if l.keyFilter != nil && !l.keyFilter(name) {
+			continue
+		}
+		out[name] = value
+	}
+	if l.foldCase {
+		out = l.foldKeyCase(path, out)
+	}
+
+	if len(out) == 0 && l.warnOnEmpty && l.logger != nil {
+		l.logger("WARNING: No parameters found under prefix %s", prefix)
+	}
+
+	return out, err
+}
+
+// foldKeyCase lowercases the relative names of the parameters under path. Of names that differ
+// only by case, the all-lowercase one wins, or else the first in sorted order; each collision is
+// logged. SSM types are made reachable under the folded names.
+func (l *Loader) foldKeyCase(path string, values map[string]string) map[string]string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	folded := make(map[string]string, len(values))
+	origins := make(map[string]string, len(values)) // folded key -> name it was taken from
+	for _, name := range names {
+		key := strings.ToLower(name)
+		if previous, ok := origins[key]; ok {
+			winner := previous
+			if name == key {
+				winner = name
+			}
+			if l.logger != nil {
+				l.logger("WARNING: Parameters %s and %s under %s differ only by case; using %s",
+					previous, name, path, winner)
+			}
+			if winner != name {
+				continue
+			}
+		}
+		folded[key] = values[name]
+		origins[key] = name
+	}
+
+	for key, name := range origins {
+		if key == name {
+			continue
+		}
+		if typ, ok := l.parameterTypes.Load(l.parameterName(path, name)); ok {
+			l.parameterTypes.Store(l.parameterName(path, key), typ)
+		}
+	}
+	return folded
+}
+
+// parameterKey returns the key of the parameter fullName fetched from the SSM path: its name
+// relative to path, or without prefix stripping its full name. Keys never start with a slash.
+func (l *Loader) parameterKey(path, fullName string) string {
+	if !l.keepPrefix {
+		if key, ok := strings.CutPrefix(fullName, strings.TrimSuffix(path, "/")+"/"); ok {
+			return key
+		}
+	}
+	return strings.TrimPrefix(fullName, "/")
+}
+
+// parameterName returns the full name of the parameter with key under the SSM path, undoing parameterKey.
+func (l *Loader) parameterName(path, key string) string {
+	if l.keepPrefix {
+		return "/" + key
+	}
+	return strings.TrimSuffix(path, "/") + "/" + key
+}
+
+// normalizePrefix returns the SSM path for a prefix, so "/myapp/", "/myapp", and "myapp"
+// all become "/myapp". An empty prefix is the root path "/".
+func normalizePrefix(prefix string) string {
+	return "/" + strings.Trim(prefix, "/")
+}
+
+// validatePrefixPath checks an SSM path (see normalizePrefix) against the rules SSM applies to
+// parameter hierarchies, so a malformed prefix fails before any call with a clear error:
+// only letters, digits, and . - _ / are allowed, levels can't be empty, and there are at most
+// 15 levels. Reserved paths such as /aws/service/... are allowed, since they can be read.
+func validatePrefixPath(path string) error {
+	if path == "/" {
+		return nil
+	}
+	levels := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for _, level := range levels {
+		if level == "" {
+			return fmt.Errorf("%w '%s': empty path level", ErrInvalidPrefix, path)
+		}
+		for _, r := range level {
+			if !isPathRune(r) {
+				return fmt.Errorf("%w '%s': character %q isn't allowed (use letters, digits, and . - _ /)",
+					ErrInvalidPrefix, path, r)
+			}
+		}
+	}
+	if len(levels) > maxPathLevels {
+		return fmt.Errorf("%w '%s': more than %d levels", ErrInvalidPrefix, path, maxPathLevels)
+	}
+	return nil
+}
+
+// isPathRune reports whether r may appear in an SSM parameter path.
+func isPathRune(r rune) bool {
+	return ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') ||
+		r == '.' || r == '-' || r == '_'
+}
+
+// ssmPrefix returns the prefix with the WithGlobalPrefix namespace prepended, if one is set.
+func (l *Loader) ssmPrefix(prefix string) string {
+	if l.globalPrefix == "" {
+		return prefix
+	}
+	return "/" + l.globalPrefix + "/" + strings.TrimLeft(prefix, "/")
+}
+
+// ssmPath returns the SSM path of a load prefix, including the global prefix.
+func (l *Loader) ssmPath(prefix string) string {
+	return normalizePrefix(l.ssmPrefix(prefix))
+}
+
+// envPrefix returns the environment variable prefix for a global prefix ("svc1" -> "SVC1_",
+// "team/svc-1" -> "TEAM_SVC_1_"), or "" if there is none.
+func envPrefix(globalPrefix string) string {
+	if globalPrefix == "" {
+		return ""
+	}
+	name := strings.Map(func(r rune) rune {
+		if ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, globalPrefix)
+	return name + "_"
+}
+
+// fetchParameters returns all parameters under the prefix keyed by their full name.
+// Parameters come from the replay recording or snapshot file if one is configured, otherwise from SSM.
+// With WithPartialResults, parameters fetched before a later page failed are returned with an
+// error wrapping ErrPartialResults; they aren't recorded.
+func (l *Loader) fetchParameters(ctx context.Context, prefix string) (map[string]string, error) {
+	if l.replayFile != "" {
+		return readRecording(l.replayFile, prefix)
+	}
+	if l.snapshotFile != "" {
+		return readSnapshot(l.snapshotFile, prefix)
+	}
+
+	var maxResults *int32
+	if l.pageSize != 0 {
+		if l.pageSize < 1 || l.pageSize > maxPageSize {
+			return nil, fmt.Errorf("page size %d out of range (1-%d)", l.pageSize, maxPageSize)
+		}
+		maxResults = ToPointerValue(l.pageSize)
+	}
+
 	out := make(map[string]string)
+	var newest time.Time
+	pages := 0
+	if span := l.spanFromContext(ctx); span != nil {
+		defer func() { span.SetAttribute(attrPages, pages) }()
+	}
 
 	var nextToken *string
 	for {
@@ -317,17 +1786,25 @@ func (l *Loader) loadFromSSM(ctx context.Context, prefix string) (map[string]str
 			Path:           &prefix,
 			Recursive:      ToPointerValue(true),
 			WithDecryption: ToPointerValue(true),
+			MaxResults:     maxResults,
 			NextToken:      nextToken,
 		})
 		if err != nil {
+			// With partial results, a failure after the first page keeps what was gathered
+			if l.partialResults && nextToken != nil {
+				return out, fmt.Errorf("%w for prefix %s (%d loaded): fetching parameters: %w",
+					ErrPartialResults, prefix, len(out), err)
+			}
 			return nil, fmt.Errorf("fetching parameters: %w", err)
 		}
+		pages++
 
 		for _, p := range resp.Parameters {
-			name := strings.TrimPrefix(*p.Name, prefix)
-			// Remove leading slash if present
-			name = strings.TrimPrefix(name, "/")
-			out[name] = *p.Value
+			out[*p.Name] = *p.Value
+			l.parameterTypes.Store(*p.Name, p.Type)
+			if p.LastModifiedDate != nil && p.LastModifiedDate.After(newest) {
+				newest = *p.LastModifiedDate
+			}
 		}
 
 		if resp.NextToken == nil {
@@ -335,17 +1812,54 @@ func (l *Loader) loadFromSSM(ctx context.Context, prefix string) (map[string]str
 		}
 		nextToken = resp.NextToken
 	}
+	l.stamps.Store(prefix, parameterStamp{newest: newest, count: len(out)})
+
+	if l.recordFile != "" {
+		if err := l.record(prefix, out); err != nil {
+			return nil, err
+		}
+	}
 
 	return out, nil
 }
 
+// GetString reads a single parameter by its full name (e.g. "/myapp/database_url").
+// SecureString parameters are decrypted. The read is not cached.
+// Returns an error wrapping ErrParameterNotFound if the parameter doesn't exist.
+// With WithGlobalPrefix, the name is namespaced like a load prefix.
+func (l *Loader) GetString(ctx context.Context, name string) (string, error) {
+	name = l.ssmPrefix(name)
+	resp, err := l.ssmClient.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           &name,
+		WithDecryption: ToPointerValue(true),
+	})
+	if err != nil {
+		var notFound *types.ParameterNotFound
+		if errors.As(err, &notFound) {
+			return "", fmt.Errorf("%w: %s", ErrParameterNotFound, name)
+		}
+		return "", fmt.Errorf("fetching parameter %s: %w", name, err)
+	}
+
+	if resp.Parameter == nil || resp.Parameter.Value == nil {
+		return "", fmt.Errorf("%w: %s", ErrParameterNotFound, name)
+	}
+
+	return *resp.Parameter.Value, nil
+}
+
 // InvalidateCache clears the cache for a specific prefix.
 // If prefix is empty, clears all cached entries.
 // After invalidation, the next call to loadByPrefix will reload from SSM.
+// With a cache backend, the next load of the prefix (or of every prefix this loader cached) calls
+// SSM instead of reading the backend and overwrites the shared entry; the entry isn't deleted.
 func (l *Loader) InvalidateCache(prefix string) {
 	if prefix == "" {
 		// Clear all cache entries
 		l.cache.Range(func(key, value interface{}) bool {
+			if cachedPrefix, ok := key.(string); ok {
+				l.refreshCacheBackend(cachedPrefix)
+			}
 			entry, ok := value.(*cacheEntry)
 			if !ok {
 				return true
@@ -360,6 +1874,7 @@ func (l *Loader) InvalidateCache(prefix string) {
 		})
 	} else {
 		// Clear specific prefix
+		l.refreshCacheBackend(prefix)
 		if entryPtr, ok := l.cache.Load(prefix); ok {
 			entry, ok := entryPtr.(*cacheEntry)
 			if !ok {
@@ -148,3 +148,39 @@ func TestLoader_LoadFromSSM(t *testing.T) {
 		_ = err
 	})
 }
+
+func TestWithCache(t *testing.T) {
+	type Config struct {
+		Host string `ssm:"host"`
+	}
+	parameters := map[string]string{"/myapp/host": "localhost"}
+
+	t.Run("caches by default", func(t *testing.T) {
+		client := newMockSSMClient(parameters)
+		loader := newLoader(client)
+
+		for i := 0; i < 2; i++ {
+			_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 1, client.calls)
+	})
+
+	t.Run("every load calls SSM when disabled", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/myapp/host": "localhost"})
+		loader := newLoader(client, WithCache(false))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "localhost", cfg.Host)
+
+		client.mu.Lock()
+		client.parameters["/myapp/host"] = "db.internal"
+		client.mu.Unlock()
+
+		cfg, err = LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "db.internal", cfg.Host)
+		assert.Equal(t, 2, client.calls)
+	})
+}
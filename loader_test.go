@@ -1,11 +1,21 @@
 package ssmconfig
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
 	"os"
+	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -253,6 +263,48 @@ func TestWithLogger(t *testing.T) {
 	})
 }
 
+func TestWithRedactor(t *testing.T) {
+	type Config struct {
+		Password string `ssm:"db/password" required:"true"`
+	}
+	redact := func(msg string) string {
+		return strings.ReplaceAll(msg, "db/password", "[REDACTED]")
+	}
+
+	t.Run("redacts every message before logging", func(t *testing.T) {
+		var logged []string
+		logger := func(format string, args ...interface{}) {
+			logged = append(logged, fmt.Sprintf(format, args...))
+		}
+		loader := newLoader(newMockSSMClient(nil), WithRedactor(redact), WithLogger(logger), WithWarnOnEmptyPrefix(true))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		require.Len(t, logged, 2)
+		assert.Equal(t, "WARNING: No parameters found under prefix /myapp/", logged[0])
+		assert.Contains(t, logged[1], "WARNING: Required field missing: field 'Password'")
+		assert.Contains(t, logged[1], "[REDACTED]")
+		assert.NotContains(t, logged[1], "db/password")
+	})
+
+	t.Run("derived loaders keep the redactor and logger", func(t *testing.T) {
+		calls := 0
+		var logged []string
+		loader := newLoader(newMockSSMClient(nil), WithLogger(func(format string, args ...interface{}) {
+			logged = append(logged, fmt.Sprintf(format, args...))
+		}))
+		derived := loader.With(WithRedactor(func(msg string) string {
+			calls++
+			return redact(msg)
+		}))
+
+		derived.logger("missing %s", "db/password")
+		loader.logger("missing %s", "db/password")
+		assert.Equal(t, []string{"missing [REDACTED]", "missing db/password"}, logged)
+		assert.Equal(t, 1, calls)
+	})
+}
+
 func TestWithStrongTyping(t *testing.T) {
 	t.Run("enables strong typing", func(t *testing.T) {
 		setupTestEnv(t)
@@ -294,3 +346,1316 @@ func TestLoadWithLoader(t *testing.T) {
 		_ = err
 	})
 }
+
+func TestWithWarnOnEmptyPrefix(t *testing.T) {
+	t.Run("logs warning when prefix has no parameters", func(t *testing.T) {
+		var loggedMessages []string
+		logger := func(format string, args ...interface{}) {
+			loggedMessages = append(loggedMessages, fmt.Sprintf(format, args...))
+		}
+
+		client := newMockSSMClient(map[string]string{"/other/value": "x"})
+		loader := newLoader(client, WithWarnOnEmptyPrefix(true), WithLogger(logger))
+
+		values, err := loader.loadFromSSM(context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Empty(t, values)
+		require.Len(t, loggedMessages, 1)
+		assert.Contains(t, loggedMessages[0], "No parameters found under prefix /test/")
+	})
+
+	t.Run("does not log when parameters exist", func(t *testing.T) {
+		var loggedMessages []string
+		logger := func(format string, args ...interface{}) {
+			loggedMessages = append(loggedMessages, fmt.Sprintf(format, args...))
+		}
+
+		client := newMockSSMClient(map[string]string{"/test/value": "x"})
+		loader := newLoader(client, WithWarnOnEmptyPrefix(true), WithLogger(logger))
+
+		_, err := loader.loadFromSSM(context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Empty(t, loggedMessages)
+	})
+
+	t.Run("does not log by default", func(t *testing.T) {
+		var loggedMessages []string
+		logger := func(format string, args ...interface{}) {
+			loggedMessages = append(loggedMessages, fmt.Sprintf(format, args...))
+		}
+
+		loader := newLoader(newMockSSMClient(map[string]string{}), WithLogger(logger))
+
+		_, err := loader.loadFromSSM(context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Empty(t, loggedMessages)
+	})
+}
+
+func TestWithFallbackPrefix(t *testing.T) {
+	type Config struct {
+		Host    string `ssm:"database/host"`
+		Port    int    `ssm:"database/port"`
+		Timeout int    `ssm:"timeout"`
+	}
+
+	t.Run("falls back to secondary prefix for missing keys", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{
+			"/myapp/default/database/host": "default-host",
+			"/myapp/default/database/port": "5432",
+			"/myapp/default/timeout":       "30",
+			"/myapp/prod/database/host":    "prod-host",
+		})
+		loader := newLoader(client, WithFallbackPrefix("/myapp/default/"))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/prod/")
+		require.NoError(t, err)
+		assert.Equal(t, "prod-host", cfg.Host)
+		assert.Equal(t, 5432, cfg.Port)
+		assert.Equal(t, 30, cfg.Timeout)
+	})
+
+	t.Run("caches each prefix independently", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{
+			"/myapp/default/timeout": "30",
+			"/myapp/prod/timeout":    "60",
+		})
+		loader := newLoader(client, WithFallbackPrefix("/myapp/default/"))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/prod/")
+		require.NoError(t, err)
+		_, err = LoadWithLoader[Config](loader, context.Background(), "/myapp/prod/")
+		require.NoError(t, err)
+		assert.Equal(t, 2, client.callCount())
+
+		_, ok := loader.cache.Load("/myapp/default/")
+		assert.True(t, ok)
+		_, ok = loader.cache.Load("/myapp/prod/")
+		assert.True(t, ok)
+	})
+
+	t.Run("ignores fallback equal to primary prefix", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/myapp/prod/timeout": "60"})
+		loader := newLoader(client, WithFallbackPrefix("/myapp/prod/"))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/prod/")
+		require.NoError(t, err)
+		assert.Equal(t, 60, cfg.Timeout)
+		assert.Equal(t, 1, client.callCount())
+	})
+}
+
+func TestWithCollectErrors(t *testing.T) {
+	type Config struct {
+		Port  int  `ssm:"port"`
+		Debug bool `ssm:"debug"`
+	}
+
+	t.Run("returns all field errors and reports each one", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{
+			"/test/port":  "abc",
+			"/test/debug": "maybe",
+		})
+
+		var failedFields []string
+		loader := newLoader(client,
+			WithCollectErrors(true),
+			WithOnFieldError(func(field string, err error) {
+				failedFields = append(failedFields, field)
+			}))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.Error(t, err)
+		assert.Nil(t, cfg)
+		assert.Contains(t, err.Error(), "setting field Port")
+		assert.Contains(t, err.Error(), "setting field Debug")
+		assert.Equal(t, []string{"Port", "Debug"}, failedFields)
+	})
+
+	t.Run("returns all validation errors together", func(t *testing.T) {
+		type ValidatedConfig struct {
+			Email    string `ssm:"email" validate:"email"`
+			Port     int    `ssm:"port" validate:"max:65535"`
+			Name     string `ssm:"name" validate:"minlen:5"`
+			Database struct {
+				Host string `ssm:"host" validate:"minlen:3"`
+			} `ssm:"database"`
+		}
+
+		client := newMockSSMClient(map[string]string{
+			"/test/email":         "not-an-email",
+			"/test/port":          "70000",
+			"/test/name":          "abc",
+			"/test/database/host": "db",
+		})
+
+		var failedFields []string
+		loader := newLoader(client,
+			WithCollectErrors(true),
+			WithOnFieldError(func(field string, err error) {
+				failedFields = append(failedFields, field)
+			}))
+
+		cfg, err := LoadWithLoader[ValidatedConfig](loader, context.Background(), "/test/")
+		require.Error(t, err)
+		assert.Nil(t, cfg)
+		assert.Contains(t, err.Error(), "field 'Email'")
+		assert.Contains(t, err.Error(), "field 'Port'")
+		assert.Contains(t, err.Error(), "field 'Name'")
+		assert.Contains(t, err.Error(), "field 'Host'")
+		assert.Equal(t, []string{"Email", "Port", "Name", "Database.Host"}, failedFields)
+	})
+
+	t.Run("stops at the first validation error by default", func(t *testing.T) {
+		type ValidatedConfig struct {
+			Email string `ssm:"email" validate:"email"`
+			Port  int    `ssm:"port" validate:"max:65535"`
+		}
+
+		client := newMockSSMClient(map[string]string{
+			"/test/email": "not-an-email",
+			"/test/port":  "70000",
+		})
+
+		_, err := LoadWithLoader[ValidatedConfig](newLoader(client), context.Background(), "/test/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field 'Email'")
+		assert.NotContains(t, err.Error(), "field 'Port'")
+	})
+}
+
+func TestLoader_GetString(t *testing.T) {
+	t.Run("returns parameter value", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/test/value": "hello"})
+		loader := newLoader(client)
+
+		value, err := loader.GetString(context.Background(), "/test/value")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", value)
+	})
+
+	t.Run("returns ErrParameterNotFound for missing parameter", func(t *testing.T) {
+		loader := newLoader(newMockSSMClient(map[string]string{}))
+
+		_, err := loader.GetString(context.Background(), "/test/missing")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, ErrParameterNotFound))
+		assert.Contains(t, err.Error(), "/test/missing")
+	})
+
+	t.Run("wraps other errors", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{})
+		client.err = errors.New("access denied")
+		loader := newLoader(client)
+
+		_, err := loader.GetString(context.Background(), "/test/value")
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, ErrParameterNotFound))
+		assert.Contains(t, err.Error(), "access denied")
+	})
+}
+
+func TestWithKeyFilter(t *testing.T) {
+	type Config struct {
+		Host string `ssm:"database/host"`
+		Port int    `ssm:"database/port"`
+		Name string `ssm:"name"`
+	}
+
+	client := newMockSSMClient(map[string]string{
+		"/shared/database/host": "db.example.com",
+		"/shared/database/port": "5432",
+		"/shared/name":          "shared",
+		"/shared/other/secret":  "unused",
+	})
+
+	t.Run("drops non-matching parameters before caching", func(t *testing.T) {
+		var seen []string
+		loader := newLoader(client, WithKeyFilter(func(name string) bool {
+			seen = append(seen, name)
+			return strings.HasPrefix(name, "database/")
+		}))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/shared/")
+		require.NoError(t, err)
+		assert.Equal(t, "db.example.com", cfg.Host)
+		assert.Equal(t, 5432, cfg.Port)
+		assert.Empty(t, cfg.Name)
+		assert.Contains(t, seen, "other/secret")
+
+		entryPtr, ok := loader.cache.Load("/shared/")
+		require.True(t, ok)
+		values := *entryPtr.(*cacheEntry).values.Load()
+		assert.Equal(t, map[string]string{
+			"database/host": "db.example.com",
+			"database/port": "5432",
+		}, values)
+	})
+
+	t.Run("keeps all parameters without a filter", func(t *testing.T) {
+		loader := newLoader(client)
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/shared/")
+		require.NoError(t, err)
+		assert.Equal(t, "shared", cfg.Name)
+	})
+}
+
+func TestLoader_With(t *testing.T) {
+	type Config struct {
+		Port int `ssm:"port" required:"true"`
+	}
+
+	t.Run("applies options on top of the base loader", func(t *testing.T) {
+		var logged []string
+		base := newLoader(newMockSSMClient(map[string]string{}),
+			WithLogger(func(format string, args ...interface{}) {
+				logged = append(logged, fmt.Sprintf(format, args...))
+			}),
+			WithConfigFiles("base.yaml"))
+
+		strict := base.With(WithStrictMode(true), WithConfigFiles("override.yaml"))
+
+		assert.False(t, base.strict)
+		assert.True(t, strict.strict)
+		assert.True(t, strict.useStrongTyping)
+		assert.Same(t, base.ssmClient, strict.ssmClient)
+		assert.Equal(t, []string{"base.yaml"}, base.configFiles)
+		assert.Equal(t, []string{"base.yaml", "override.yaml"}, strict.configFiles)
+
+		strict.logger("from clone")
+		assert.Equal(t, []string{"from clone"}, logged)
+	})
+
+	t.Run("strict clone fails while base only logs", func(t *testing.T) {
+		base := newLoader(newMockSSMClient(map[string]string{}))
+		strict := base.With(WithStrictMode(true))
+
+		_, err := LoadWithLoader[Config](base, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		_, err = LoadWithLoader[Config](strict, context.Background(), "/myapp/")
+		require.Error(t, err)
+	})
+
+	t.Run("clone has its own cache", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/myapp/port": "8080"})
+		base := newLoader(client)
+
+		_, err := LoadWithLoader[Config](base, context.Background(), "/myapp/")
+		require.NoError(t, err)
+
+		clone := base.With()
+		_, ok := clone.cache.Load("/myapp/")
+		assert.False(t, ok)
+
+		_, err = LoadWithLoader[Config](clone, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, 2, client.callCount())
+
+		clone.InvalidateCache("")
+		_, ok = base.cache.Load("/myapp/")
+		assert.True(t, ok)
+	})
+}
+
+func TestWithStrictPanic(t *testing.T) {
+	type Config struct {
+		APIKey string `ssm:"api_key" required:"true"`
+	}
+
+	t.Run("strict mode failure is returned as error", func(t *testing.T) {
+		loader := newLoader(newMockSSMClient(map[string]string{}), WithStrictMode(true))
+
+		var cfg *Config
+		var err error
+		assert.NotPanics(t, func() {
+			cfg, err = LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		})
+		require.Error(t, err)
+		assert.Nil(t, cfg)
+		assert.EqualError(t, err, "ssmconfig: Missing required fields: field 'APIKey' (ssm:'api_key', env:'')")
+		assert.ErrorIs(t, err, ErrMissingRequired)
+		var missing *MissingRequiredError
+		require.ErrorAs(t, err, &missing)
+		assert.Equal(t, []string{"field 'APIKey' (ssm:'api_key', env:'')"}, missing.Fields)
+	})
+
+	t.Run("strict panic keeps the panic", func(t *testing.T) {
+		loader := newLoader(newMockSSMClient(map[string]string{}), WithStrictMode(true), WithStrictPanic(true))
+
+		assert.PanicsWithValue(t, "ssmconfig: Missing required fields: field 'APIKey' (ssm:'api_key', env:'')", func() {
+			_, _ = LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		})
+	})
+
+	t.Run("other panics aren't turned into errors", func(t *testing.T) {
+		RegisterValidator("panics", func(interface{}) error { panic("validator bug") })
+		defer UnregisterValidator("panics")
+		type Validated struct {
+			Host string `ssm:"host" validate:"panics"`
+		}
+		client := newMockSSMClient(map[string]string{"/myapp/host": "localhost"})
+
+		assert.PanicsWithValue(t, "validator bug", func() {
+			_, _ = LoadWithLoader[Validated](newLoader(client, WithStrictMode(true)), context.Background(), "/myapp/")
+		})
+		assert.PanicsWithValue(t, "validator bug", func() {
+			_, _ = MapInto[Validated](map[string]string{"host": "localhost"})
+		})
+	})
+
+	t.Run("strict panic without strict mode only logs", func(t *testing.T) {
+		loader := newLoader(newMockSSMClient(map[string]string{}), WithStrictPanic(true))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Empty(t, cfg.APIKey)
+	})
+}
+
+func TestLoader_PrefixNormalization(t *testing.T) {
+	client := newMockSSMClient(map[string]string{
+		"/myapp/database/host": "db.example.com",
+		"/myapp/port":          "8080",
+	})
+	expected := map[string]string{
+		"database/host": "db.example.com",
+		"port":          "8080",
+	}
+
+	for _, prefix := range []string{"/myapp/", "/myapp", "myapp", "myapp/"} {
+		t.Run(prefix, func(t *testing.T) {
+			loader := newLoader(client)
+			values, err := loader.loadFromSSM(context.Background(), prefix)
+			require.NoError(t, err)
+			assert.Equal(t, expected, values)
+		})
+	}
+
+	t.Run("normalizePrefix", func(t *testing.T) {
+		assert.Equal(t, "/myapp", normalizePrefix("/myapp/"))
+		assert.Equal(t, "/myapp/prod", normalizePrefix("myapp/prod/"))
+		assert.Equal(t, "/", normalizePrefix(""))
+		assert.Equal(t, "/", normalizePrefix("/"))
+	})
+}
+
+func TestWithTagName(t *testing.T) {
+	type Database struct {
+		Host string `cfg:"host" ssm:"ignored"`
+		Port int    `cfg:"port"`
+	}
+
+	type Shared struct {
+		Region string `cfg:"region"`
+	}
+
+	type Config struct {
+		Name     string   `cfg:"name" required:"true"`
+		APIKey   string   `cfg:"api_key" env:"TEST_TAGNAME_API_KEY"`
+		Database Database `cfg:"database"`
+		Shared   Shared   `cfg:",squash"`
+	}
+
+	client := newMockSSMClient(map[string]string{
+		"/test/name":          "app",
+		"/test/api_key":       "ssm-key",
+		"/test/database/host": "db.internal",
+		"/test/database/port": "5432",
+		"/test/region":        "eu-west-1",
+		"/test/ignored":       "wrong",
+	})
+	t.Setenv("TEST_TAGNAME_API_KEY", "env-key")
+
+	loader := newLoader(client, WithTagName("cfg"), WithStrictMode(true))
+	cfg, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+	require.NoError(t, err)
+	assert.Equal(t, "app", cfg.Name)
+	assert.Equal(t, "env-key", cfg.APIKey)
+	assert.Equal(t, Database{Host: "db.internal", Port: 5432}, cfg.Database)
+	assert.Equal(t, "eu-west-1", cfg.Shared.Region)
+}
+
+func TestLoadMergedWithLoader(t *testing.T) {
+	type Config struct {
+		Region   string `ssm:"region"`
+		LogLevel string `ssm:"log_level"`
+		Name     string `ssm:"name"`
+	}
+
+	client := newMockSSMClient(map[string]string{
+		"/shared/region":    "eu-west-1",
+		"/shared/log_level": "info",
+		"/myapp/log_level":  "debug",
+		"/myapp/name":       "app",
+	})
+
+	t.Run("later prefixes override earlier ones", func(t *testing.T) {
+		loader := newLoader(client)
+
+		cfg, err := LoadMergedWithLoader[Config](loader, context.Background(), []string{"/shared/", "/myapp/"})
+		require.NoError(t, err)
+		assert.Equal(t, "eu-west-1", cfg.Region)
+		assert.Equal(t, "debug", cfg.LogLevel)
+		assert.Equal(t, "app", cfg.Name)
+	})
+
+	t.Run("caches each prefix independently", func(t *testing.T) {
+		loader := newLoader(client)
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/shared/")
+		require.NoError(t, err)
+		calls := client.callCount()
+
+		_, err = LoadMergedWithLoader[Config](loader, context.Background(), []string{"/shared/", "/myapp/"})
+		require.NoError(t, err)
+		assert.Equal(t, calls+1, client.callCount())
+	})
+
+	t.Run("requires at least one prefix", func(t *testing.T) {
+		_, err := LoadMergedWithLoader[Config](newLoader(client), context.Background(), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestWithLoadTimeout(t *testing.T) {
+	type Config struct {
+		Value string `ssm:"value"`
+	}
+
+	t.Run("fails when the load exceeds the budget", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/test/value": "x"})
+		client.delay = time.Second
+		loader := newLoader(client, WithLoadTimeout(20*time.Millisecond))
+
+		start := time.Now()
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "load exceeded timeout of 20ms")
+		assert.Less(t, time.Since(start), 500*time.Millisecond)
+	})
+
+	t.Run("a timed-out load doesn't poison the cache", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/test/value": "x"})
+		client.delay = time.Second
+		loader := newLoader(client, WithLoadTimeout(20*time.Millisecond))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.Error(t, err)
+
+		client.delay = 0
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "x", cfg.Value)
+	})
+
+	t.Run("succeeds within the budget", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/test/value": "x"})
+		loader := newLoader(client, WithLoadTimeout(time.Second))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "x", cfg.Value)
+	})
+}
+
+func TestWithPageSize(t *testing.T) {
+	parameters := make(map[string]string)
+	for i := 0; i < 7; i++ {
+		parameters[fmt.Sprintf("/test/key%d", i)] = fmt.Sprintf("value%d", i)
+	}
+
+	t.Run("requests pages of the configured size and concatenates them", func(t *testing.T) {
+		client := newMockSSMClient(parameters)
+		loader := newLoader(client, WithPageSize(3))
+
+		values, err := loader.loadFromSSM(context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Len(t, values, 7)
+		assert.Equal(t, "value6", values["key6"])
+
+		require.Len(t, client.maxResults, 3) // 3 + 3 + 1
+		for _, maxResults := range client.maxResults {
+			require.NotNil(t, maxResults)
+			assert.Equal(t, int32(3), *maxResults)
+		}
+	})
+
+	t.Run("uses the API default when unset", func(t *testing.T) {
+		client := newMockSSMClient(parameters)
+		loader := newLoader(client)
+
+		_, err := loader.loadFromSSM(context.Background(), "/test/")
+		require.NoError(t, err)
+		require.Len(t, client.maxResults, 1)
+		assert.Nil(t, client.maxResults[0])
+	})
+
+	t.Run("rejects out-of-range sizes", func(t *testing.T) {
+		loader := newLoader(newMockSSMClient(parameters), WithPageSize(11))
+
+		_, err := loader.loadFromSSM(context.Background(), "/test/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "page size 11 out of range (1-10)")
+	})
+}
+
+func TestWithPartialResults(t *testing.T) {
+	parameters := make(map[string]string)
+	for i := 0; i < 5; i++ {
+		parameters[fmt.Sprintf("/test/key%d", i)] = fmt.Sprintf("value%d", i)
+	}
+
+	newFailingClient := func() *mockSSMClient {
+		client := newMockSSMClient(parameters)
+		client.err = errors.New("throttled")
+		client.failAfter = 1 // The first page succeeds
+		return client
+	}
+
+	t.Run("keeps the pages fetched before the failure", func(t *testing.T) {
+		loader := newLoader(newFailingClient(), WithPageSize(2), WithPartialResults(true))
+
+		values, err := loader.loadFromSSM(context.Background(), "/test/")
+		require.ErrorIs(t, err, ErrPartialResults)
+		assert.Contains(t, err.Error(), "partial parameters for prefix /test (2 loaded)")
+		assert.Contains(t, err.Error(), "throttled")
+		assert.Equal(t, map[string]string{"key0": "value0", "key1": "value1"}, values)
+	})
+
+	t.Run("returns the config with the error and never caches it", func(t *testing.T) {
+		type Config struct {
+			Key0 string `ssm:"key0"`
+			Key4 string `ssm:"key4"`
+		}
+		client := newFailingClient()
+		backend := NewMemoryCache()
+		loader := newLoader(client, WithPageSize(2), WithPartialResults(true), WithCacheBackend(backend))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.ErrorIs(t, err, ErrPartialResults)
+		require.NotNil(t, cfg)
+		assert.Equal(t, Config{Key0: "value0"}, *cfg)
+		_, found, err := backend.Get(context.Background(), "ssmconfig:/test")
+		require.NoError(t, err)
+		assert.False(t, found)
+
+		// The next load calls SSM again instead of serving the partial set
+		client.mu.Lock()
+		client.err = nil
+		client.mu.Unlock()
+		cfg, err = LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, Config{Key0: "value0", Key4: "value4"}, *cfg)
+	})
+
+	t.Run("fails by default", func(t *testing.T) {
+		loader := newLoader(newFailingClient(), WithPageSize(2))
+
+		_, err := loader.loadFromSSM(context.Background(), "/test/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "throttled")
+	})
+
+	t.Run("fails when the first page fails", func(t *testing.T) {
+		client := newMockSSMClient(parameters)
+		client.err = errors.New("throttled")
+		loader := newLoader(client, WithPageSize(2), WithPartialResults(true))
+
+		_, err := loader.loadFromSSM(context.Background(), "/test/")
+		require.Error(t, err)
+	})
+}
+
+func TestNewLoaderFromConfig(t *testing.T) {
+	loader := NewLoaderFromConfig(aws.Config{Region: "eu-west-1"}, WithStrictMode(true))
+	require.NotNil(t, loader)
+
+	client, ok := loader.ssmClient.(*ssm.Client)
+	require.True(t, ok)
+	assert.Equal(t, "eu-west-1", client.Options().Region)
+	assert.True(t, loader.strict)
+	assert.True(t, loader.useStrongTyping)
+}
+
+func TestWithDefaultLogger(t *testing.T) {
+	type Config struct {
+		APIKey string `ssm:"api_key" required:"true"`
+	}
+
+	var buf bytes.Buffer
+	out, flags := log.Writer(), log.Flags()
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(out)
+		log.SetFlags(flags)
+	}()
+
+	t.Run("logs through the standard logger with a prefix", func(t *testing.T) {
+		buf.Reset()
+		loader := newLoader(newMockSSMClient(map[string]string{}), WithDefaultLogger())
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "ssmconfig: WARNING: Required field missing: field 'APIKey' (ssm:'api_key', env:'')\n",
+			buf.String())
+	})
+
+	t.Run("a later WithLogger overrides it", func(t *testing.T) {
+		buf.Reset()
+		var logged []string
+		loader := newLoader(newMockSSMClient(map[string]string{}),
+			WithDefaultLogger(),
+			WithLogger(func(format string, args ...interface{}) {
+				logged = append(logged, fmt.Sprintf(format, args...))
+			}))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Empty(t, buf.String())
+		assert.Len(t, logged, 1)
+	})
+}
+
+func TestMapInto(t *testing.T) {
+	values := map[string]string{
+		"host":          "db.example.com",
+		"port":          "5432",
+		"database/name": "orders",
+		"url":           "postgres://${ref:host}:${ref:port}",
+	}
+
+	t.Run("maps values without a client", func(t *testing.T) {
+		type Config struct {
+			Host     string `ssm:"host"`
+			Port     int    `ssm:"port"`
+			Database struct {
+				Name string `ssm:"name"`
+			} `ssm:"database"`
+		}
+
+		cfg, err := MapInto[Config](values)
+		require.NoError(t, err)
+		assert.Equal(t, "db.example.com", cfg.Host)
+		assert.Equal(t, 5432, cfg.Port)
+		assert.Equal(t, "orders", cfg.Database.Name)
+	})
+
+	t.Run("maps one fetch into several structs", func(t *testing.T) {
+		type ServerConfig struct {
+			Port int `ssm:"port" env:"TEST_MAPINTO_PORT"`
+		}
+		type DatabaseConfig struct {
+			URL string `ssm:"url"`
+		}
+		t.Setenv("TEST_MAPINTO_PORT", "8080")
+
+		server, err := MapInto[ServerConfig](values)
+		require.NoError(t, err)
+		assert.Equal(t, 8080, server.Port)
+
+		database, err := MapInto[DatabaseConfig](values, WithInterpolation(true))
+		require.NoError(t, err)
+		assert.Equal(t, "postgres://db.example.com:5432", database.URL)
+		assert.Equal(t, "postgres://${ref:host}:${ref:port}", values["url"])
+	})
+
+	t.Run("applies mapping options", func(t *testing.T) {
+		type Config struct {
+			Host    string `ssm:"host"`
+			Missing string `ssm:"missing" required:"true"`
+		}
+
+		_, err := MapInto[Config](values, WithStrictMode(true))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Missing required fields: field 'Missing'")
+
+		cfg, err := MapInto[Config](values)
+		require.NoError(t, err)
+		assert.Equal(t, "db.example.com", cfg.Host)
+	})
+
+	t.Run("reports conversion errors", func(t *testing.T) {
+		type Config struct {
+			Host int `ssm:"host"`
+		}
+
+		_, err := MapInto[Config](values)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mapping to struct: setting field Host")
+	})
+}
+
+func TestWithListNestedRequired(t *testing.T) {
+	type TLS struct {
+		CertFile string `ssm:"cert_file" required:"true"`
+	}
+	type Database struct {
+		Host     string `ssm:"host" required:"true"`
+		Password string `ssm:"password" env:"TEST_LISTNESTED_PASSWORD" required:"true"`
+		Port     int    `ssm:"port"`
+		TLS      TLS    `ssm:"tls" required:"true"`
+		Replica  struct {
+			Host string `ssm:"host" required:"true"`
+		} `ssm:"replica"`
+		Cache struct {
+			Host string `ssm:"host" required:"true"`
+		} `ssm:"cache" gate:"cache/enabled"`
+	}
+	type Config struct {
+		Database Database `ssm:"database" required:"true"`
+	}
+
+	t.Run("lists the required fields of a missing nested struct", func(t *testing.T) {
+		var logged []string
+		loader := newLoader(newMockSSMClient(map[string]string{}),
+			WithStrictMode(true), WithListNestedRequired(true),
+			WithLogger(func(format string, args ...interface{}) {
+				logged = append(logged, fmt.Sprintf(format, args...))
+			}))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.EqualError(t, err, "ssmconfig: Missing required fields: "+
+			"nested struct field 'Database' (ssm:'database', env:''), "+
+			"field 'Database.Host' (ssm:'database/host', env:''), "+
+			"field 'Database.Password' (ssm:'database/password', env:'TEST_LISTNESTED_PASSWORD'), "+
+			"nested struct field 'Database.TLS' (ssm:'database/tls', env:''), "+
+			"field 'Database.TLS.CertFile' (ssm:'database/tls/cert_file', env:''), "+
+			"field 'Database.Replica.Host' (ssm:'database/replica/host', env:'')")
+		assert.Len(t, logged, 6)
+	})
+
+	t.Run("lists a self-referential struct once", func(t *testing.T) {
+		type node struct {
+			Name string `ssm:"name" required:"true"`
+			Next *node  `ssm:"next" required:"true"`
+		}
+		type Tree struct {
+			Root node `ssm:"root" required:"true"`
+		}
+		loader := newLoader(newMockSSMClient(map[string]string{}), WithStrictMode(true), WithListNestedRequired(true))
+
+		_, err := LoadWithLoader[Tree](loader, context.Background(), "/myapp/")
+		assert.EqualError(t, err, "ssmconfig: Missing required fields: "+
+			"nested struct field 'Root' (ssm:'root', env:''), "+
+			"field 'Root.Name' (ssm:'root/name', env:''), "+
+			"nested struct field 'Root.Next' (ssm:'root/next', env:'')")
+	})
+
+	t.Run("reports only the struct by default", func(t *testing.T) {
+		loader := newLoader(newMockSSMClient(map[string]string{}), WithStrictMode(true))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.EqualError(t, err,
+			"ssmconfig: Missing required fields: nested struct field 'Database' (ssm:'database', env:'')")
+	})
+}
+
+func TestWithOnLoad(t *testing.T) {
+	type Config struct {
+		Host string `ssm:"host"`
+		Port int    `ssm:"port"`
+	}
+	client := newMockSSMClient(map[string]string{"/myapp/host": "db.example.com", "/myapp/port": "5432"})
+
+	t.Run("receives the config and raw values after a successful load", func(t *testing.T) {
+		var gotConfig any
+		var gotRaw map[string]string
+		loader := newLoader(client, WithOnLoad(func(cfg any, raw map[string]string) {
+			gotConfig, gotRaw = cfg, raw
+		}))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Same(t, cfg, gotConfig)
+		assert.Equal(t, map[string]string{"host": "db.example.com", "port": "5432"}, gotRaw)
+	})
+
+	t.Run("is not called when the load fails", func(t *testing.T) {
+		type BadConfig struct {
+			Host int `ssm:"host"`
+		}
+		called := false
+		loader := newLoader(client, WithOnLoad(func(any, map[string]string) { called = true }))
+
+		_, err := LoadWithLoader[BadConfig](loader, context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.False(t, called)
+	})
+
+	t.Run("a panicking callback is logged and doesn't fail the load", func(t *testing.T) {
+		var logged []string
+		loader := newLoader(client,
+			WithOnLoad(func(any, map[string]string) { panic("cache unavailable") }),
+			WithLogger(func(format string, args ...interface{}) {
+				logged = append(logged, fmt.Sprintf(format, args...))
+			}))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "db.example.com", cfg.Host)
+		assert.Equal(t, []string{"WARNING: OnLoad callback failed: cache unavailable"}, logged)
+	})
+}
+
+func TestWithCaseInsensitiveKeys(t *testing.T) {
+	type Config struct {
+		APIKey   string   `ssm:"api_key"`
+		Regions  []string `ssm:"Regions"`
+		Database struct {
+			Host string `ssm:"host" required:"true"`
+		} `ssm:"Database"`
+	}
+
+	newClient := func() *mockSSMClient {
+		client := newMockSSMClient(map[string]string{
+			"/myapp/API_Key":       "secret",
+			"/myapp/regions":       "us-east-1,eu-west-1",
+			"/myapp/DataBase/Host": "db.example.com",
+		})
+		client.parameterTypes = map[string]types.ParameterType{"/myapp/regions": types.ParameterTypeStringList}
+		return client
+	}
+
+	t.Run("matches keys regardless of case", func(t *testing.T) {
+		loader := newLoader(newClient(), WithCaseInsensitiveKeys(true), WithRequiredKeys("/myapp/Api_Key"))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "secret", cfg.APIKey)
+		assert.Equal(t, []string{"us-east-1", "eu-west-1"}, cfg.Regions)
+		assert.Equal(t, "db.example.com", cfg.Database.Host)
+	})
+
+	t.Run("keys are case-sensitive by default", func(t *testing.T) {
+		cfg, err := LoadWithLoader[Config](newLoader(newClient()), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Empty(t, cfg.APIKey)
+		assert.Empty(t, cfg.Regions)
+		assert.Empty(t, cfg.Database.Host)
+	})
+
+	t.Run("case-only collisions are resolved deterministically and logged", func(t *testing.T) {
+		var logged []string
+		client := newMockSSMClient(map[string]string{
+			"/myapp/Database/Host": "upper.example.com",
+			"/myapp/database/host": "lower.example.com",
+			"/myapp/LOG_LEVEL":     "debug",
+			"/myapp/Log_Level":     "info",
+		})
+		loader := newLoader(client, WithCaseInsensitiveKeys(true),
+			WithLogger(func(format string, args ...interface{}) {
+				logged = append(logged, fmt.Sprintf(format, args...))
+			}))
+
+		values, err := loader.loadByPrefix(context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"database/host": "lower.example.com", "log_level": "debug"}, values)
+		assert.Equal(t, []string{
+			"WARNING: Parameters LOG_LEVEL and Log_Level under /myapp differ only by case; using LOG_LEVEL",
+			"WARNING: Parameters Database/Host and database/host under /myapp differ only by case; using database/host",
+		}, logged)
+	})
+}
+
+func TestWithStripPrefix(t *testing.T) {
+	type Config struct {
+		Host    string   `ssm:"database/host"`
+		Regions []string `ssm:"regions"`
+	}
+	newClient := func() *mockSSMClient {
+		client := newMockSSMClient(map[string]string{
+			"/myapp/database/host":     "db.example.com",
+			"/myapp/regions":           "us-east-1,eu-west-1",
+			"/myapp-staging/log_level": "debug",
+		})
+		client.parameterTypes = map[string]types.ParameterType{"/myapp/regions": types.ParameterTypeStringList}
+		return client
+	}
+
+	for _, prefix := range []string{"/myapp/", "/myapp", "myapp/", "myapp"} {
+		t.Run("strips "+prefix, func(t *testing.T) {
+			loader := newLoader(newClient(), WithRequiredKeys("database/host", "/myapp/regions"))
+
+			values, err := loader.loadByPrefix(context.Background(), prefix)
+			require.NoError(t, err)
+			// Only whole path segments are stripped, so the sibling /myapp-staging keeps its name
+			assert.Equal(t, map[string]string{
+				"database/host":           "db.example.com",
+				"regions":                 "us-east-1,eu-west-1",
+				"myapp-staging/log_level": "debug",
+			}, values)
+
+			cfg, err := LoadWithLoader[Config](loader, context.Background(), prefix)
+			require.NoError(t, err)
+			assert.Equal(t, "db.example.com", cfg.Host)
+			assert.Equal(t, []string{"us-east-1", "eu-west-1"}, cfg.Regions)
+		})
+	}
+
+	t.Run("keeps the full path when disabled", func(t *testing.T) {
+		type FullConfig struct {
+			Host    string   `ssm:"myapp/database/host"`
+			Regions []string `ssm:"myapp/regions"`
+		}
+		loader := newLoader(newClient(), WithStripPrefix(false), WithRequiredKeys("/myapp/database/host"))
+
+		values, err := loader.loadByPrefix(context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"myapp/database/host":     "db.example.com",
+			"myapp/regions":           "us-east-1,eu-west-1",
+			"myapp-staging/log_level": "debug",
+		}, values)
+
+		cfg, err := LoadWithLoader[FullConfig](loader, context.Background(), "/myapp")
+		require.NoError(t, err)
+		assert.Equal(t, "db.example.com", cfg.Host)
+		assert.Equal(t, []string{"us-east-1", "eu-west-1"}, cfg.Regions)
+
+		_, err = LoadWithLoader[FullConfig](loader.With(WithRequiredKeys("database/host")), context.Background(), "/myapp")
+		assert.ErrorContains(t, err, "missing required keys: database/host")
+	})
+
+	t.Run("root prefix", func(t *testing.T) {
+		values, err := newLoader(newClient()).loadByPrefix(context.Background(), "/")
+		require.NoError(t, err)
+		assert.Contains(t, values, "myapp/database/host")
+	})
+}
+
+func TestLoadReturnsNoPartialConfig(t *testing.T) {
+	type Config struct {
+		Host    string `ssm:"host"`
+		Port    int    `ssm:"port"`
+		Email   string `ssm:"email" validate:"email"`
+		Token   string `ssm:"token"`
+		Timeout int    `ssm:"timeout" required:"true"`
+	}
+	valid := map[string]string{
+		"/myapp/host":    "db.example.com",
+		"/myapp/port":    "5432",
+		"/myapp/email":   "ops@example.com",
+		"/myapp/token":   "secret",
+		"/myapp/timeout": "30",
+	}
+	with := func(key, value string) map[string]string {
+		params := maps.Clone(valid)
+		if value == "" {
+			delete(params, key)
+		} else {
+			params[key] = value
+		}
+		return params
+	}
+
+	tests := []struct {
+		name   string
+		params map[string]string
+		opts   []LoaderOption
+	}{
+		{"validator fails after earlier fields are mapped", with("/myapp/email", "not-an-email"), nil},
+		{"conversion fails", with("/myapp/port", "http"), nil},
+		{"collected field errors", with("/myapp/port", "http"), []LoaderOption{WithCollectErrors(true)}},
+		{"strict-mode missing required field", with("/myapp/timeout", ""), []LoaderOption{WithStrictMode(true)}},
+		{"struct-level constraint", valid, []LoaderOption{WithMutuallyExclusive("Token", "Host")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded := false
+			opts := append([]LoaderOption{WithOnLoad(func(any, map[string]string) { loaded = true })}, tt.opts...)
+			loader := newLoader(newMockSSMClient(tt.params), opts...)
+
+			cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+			require.Error(t, err)
+			assert.Nil(t, cfg)
+			assert.False(t, loaded)
+
+			values := make(map[string]string, len(tt.params))
+			for name, value := range tt.params {
+				values[strings.TrimPrefix(name, "/myapp/")] = value
+			}
+			mapped, err := MapInto[Config](values, tt.opts...)
+			require.Error(t, err)
+			assert.Nil(t, mapped)
+		})
+	}
+
+	t.Run("the full config once everything passes", func(t *testing.T) {
+		cfg, err := LoadWithLoader[Config](newLoader(newMockSSMClient(valid)), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t,
+			&Config{Host: "db.example.com", Port: 5432, Email: "ops@example.com", Token: "secret", Timeout: 30}, cfg)
+	})
+}
+
+func TestWithUnsetSentinel(t *testing.T) {
+	type Config struct {
+		Host     string   `ssm:"host"`
+		Port     int      `ssm:"port" default:"8080"`
+		Regions  []string `ssm:"regions"`
+		Region   string   `ssm:"region" env:"TEST_UNSET_REGION"`
+		Database struct {
+			Host string `json:"host"`
+		} `ssm:"database" json:"true"`
+	}
+	client := newMockSSMClient(map[string]string{
+		"/myapp/host":     "__UNSET__",
+		"/myapp/port":     "__UNSET__",
+		"/myapp/regions":  "__UNSET__",
+		"/myapp/region":   "eu-west-1",
+		"/myapp/database": "__UNSET__",
+	})
+
+	t.Run("leaves fields at their zero value, ignoring defaults", func(t *testing.T) {
+		t.Setenv("TEST_UNSET_REGION", "__UNSET__")
+		loader := newLoader(client, WithUnsetSentinel("__UNSET__"))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, &Config{}, cfg)
+	})
+
+	t.Run("a cleared required field is reported missing", func(t *testing.T) {
+		type RequiredConfig struct {
+			Host string `ssm:"host" required:"true"`
+		}
+		loader := newLoader(client, WithUnsetSentinel("__UNSET__"), WithStrictMode(true))
+
+		_, err := LoadWithLoader[RequiredConfig](loader, context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Missing required fields: field 'Host'")
+	})
+
+	t.Run("the sentinel is an ordinary value without the option", func(t *testing.T) {
+		type HostConfig struct {
+			Host string `ssm:"host"`
+		}
+		cfg, err := LoadWithLoader[HostConfig](newLoader(client), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "__UNSET__", cfg.Host)
+	})
+}
+
+func TestWithMergeStrategy(t *testing.T) {
+	type Config struct {
+		Regions []string `ssm:"regions"`
+		Host    string   `ssm:"host"`
+	}
+	client := newMockSSMClient(map[string]string{
+		"/myapp/regions": "us-east-1,eu-west-1",
+		"/myapp/host":    "ssm.internal",
+	})
+	file := WithConfigBytes("yaml", []byte("regions: eu-west-1,ap-south-1\nhost: file.internal\n"))
+
+	// union merges comma-separated lists additively, keeping the first occurrence of each item
+	union := func(_, ssmVal, fileVal string) string {
+		var items []string
+		seen := make(map[string]bool)
+		for _, item := range strings.Split(ssmVal+","+fileVal, ",") {
+			if item = strings.TrimSpace(item); item != "" && !seen[item] {
+				seen[item] = true
+				items = append(items, item)
+			}
+		}
+		return strings.Join(items, ",")
+	}
+
+	t.Run("file values win by default", func(t *testing.T) {
+		cfg, err := LoadWithLoader[Config](newLoader(client, file), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"eu-west-1", "ap-south-1"}, cfg.Regions)
+		assert.Equal(t, "file.internal", cfg.Host)
+	})
+
+	t.Run("merges overlapping keys with the strategy", func(t *testing.T) {
+		var merged []string
+		loader := newLoader(client, file, WithMergeStrategy(func(key, ssmVal, fileVal string) string {
+			merged = append(merged, key)
+			if key == "regions" {
+				return union(key, ssmVal, fileVal)
+			}
+			return fileVal
+		}))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"us-east-1", "eu-west-1", "ap-south-1"}, cfg.Regions)
+		assert.Equal(t, "file.internal", cfg.Host)
+		assert.ElementsMatch(t, []string{"regions", "host"}, merged)
+	})
+
+	t.Run("keys only in one source aren't merged", func(t *testing.T) {
+		called := false
+		loader := newLoader(client, WithConfigBytes("yaml", []byte("port: 8080\n")),
+			WithMergeStrategy(func(_, _, fileVal string) string {
+				called = true
+				return fileVal
+			}))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.False(t, called)
+	})
+}
+
+func TestWithLenientJSONScalars(t *testing.T) {
+	type Config struct {
+		Name    string  `ssm:"name"`
+		Quoted  string  `ssm:"quoted"`
+		Number  string  `ssm:"number"`
+		Pointer *string `ssm:"pointer"`
+		Port    int     `ssm:"port"`
+	}
+	client := newMockSSMClient(map[string]string{
+		"/myapp/name":    "hello",
+		"/myapp/quoted":  `"hello"`,
+		"/myapp/number":  "8080",
+		"/myapp/pointer": "world",
+		"/myapp/port":    "8080",
+	})
+
+	t.Run("unquoted strings fail in JSON mode by default", func(t *testing.T) {
+		loader := newLoader(client, WithStrongTyping(false))
+
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "decoding JSON for field Name")
+	})
+
+	t.Run("takes non-JSON values literally", func(t *testing.T) {
+		loader := newLoader(client, WithStrongTyping(false), WithLenientJSONScalars(true))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", cfg.Name)
+		assert.Equal(t, "hello", cfg.Quoted, "a JSON string is still decoded")
+		assert.Equal(t, "8080", cfg.Number)
+		assert.Equal(t, ToPointerValue("world"), cfg.Pointer)
+		assert.Equal(t, 8080, cfg.Port)
+	})
+
+	t.Run("other types are still strict", func(t *testing.T) {
+		type PortConfig struct {
+			Port int `ssm:"name"`
+		}
+		loader := newLoader(client, WithStrongTyping(false), WithLenientJSONScalars(true))
+
+		_, err := LoadWithLoader[PortConfig](loader, context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "decoding JSON for field Port")
+	})
+}
+
+func TestWithGlobalPrefix(t *testing.T) {
+	type Database struct {
+		Host string `ssm:"host" env:"DB_HOST"`
+		Port int    `ssm:"port"`
+	}
+	type Config struct {
+		Name     string   `ssm:"name" env:"APP_NAME"`
+		Region   string   `ssm:"region"`
+		Database Database `ssm:"database"`
+	}
+	client := newMockSSMClient(map[string]string{
+		"/svc1/myapp/name":          "svc1",
+		"/svc1/myapp/database/host": "db1.internal",
+		"/svc1/myapp/database/port": "5432",
+		"/svc1/shared/region":       "eu-west-1",
+		"/myapp/name":               "unprefixed",
+		"/myapp/database/host":      "db.internal",
+	})
+
+	t.Run("namespaces SSM paths, including nested keys", func(t *testing.T) {
+		loader := newLoader(client, WithGlobalPrefix("svc1"), WithFallbackPrefix("/shared/"))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, &Config{
+			Name:     "svc1",
+			Region:   "eu-west-1",
+			Database: Database{Host: "db1.internal", Port: 5432},
+		}, cfg)
+
+		value, err := loader.GetString(context.Background(), "/myapp/database/host")
+		require.NoError(t, err)
+		assert.Equal(t, "db1.internal", value)
+	})
+
+	t.Run("reports the full resolved keys and env names", func(t *testing.T) {
+		loader := newLoader(client, WithGlobalPrefix("/svc1/"))
+
+		reports, err := ExplainWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		require.Len(t, reports, 4)
+		assert.Equal(t, "/svc1/myapp/database/host", reports[2].Key)
+		assert.Equal(t, "SVC1_DB_HOST", reports[2].Env)
+		assert.Equal(t, "/svc1/myapp/database/port", reports[3].Key)
+	})
+
+	t.Run("namespaces env tags", func(t *testing.T) {
+		t.Setenv("DB_HOST", "unprefixed.internal")
+		t.Setenv("SVC1_DB_HOST", "env1.internal")
+		t.Setenv("TEAM_SVC_1_APP_NAME", "team")
+
+		cfg, err := LoadWithLoader[Config](newLoader(client, WithGlobalPrefix("svc1")), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "env1.internal", cfg.Database.Host)
+
+		cfg, err = LoadWithLoader[Config](newLoader(client, WithGlobalPrefix("team/svc-1")), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "team", cfg.Name)
+	})
+
+	t.Run("without a global prefix nothing changes", func(t *testing.T) {
+		cfg, err := LoadWithLoader[Config](newLoader(client), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "unprefixed", cfg.Name)
+		assert.Equal(t, "db.internal", cfg.Database.Host)
+	})
+}
+
+func TestLoadValidatesPrefix(t *testing.T) {
+	type Config struct {
+		Host string `ssm:"host"`
+	}
+
+	t.Run("rejects malformed prefixes before calling SSM", func(t *testing.T) {
+		tests := []struct {
+			prefix string
+			reason string
+		}{
+			{prefix: "/my app/", reason: "character ' ' isn't allowed"},
+			{prefix: "/myapp/*", reason: "character '*' isn't allowed"},
+			{prefix: "/myapp/${env}/", reason: "character '$' isn't allowed"},
+			{prefix: "/myapp//db", reason: "empty path level"},
+			{prefix: "/" + strings.Repeat("a/", 16), reason: "more than 15 levels"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.prefix, func(t *testing.T) {
+				client := newMockSSMClient(nil)
+
+				_, err := LoadWithLoader[Config](newLoader(client), context.Background(), tt.prefix)
+				require.ErrorIs(t, err, ErrInvalidPrefix)
+				assert.Contains(t, err.Error(), "invalid SSM path prefix")
+				assert.Contains(t, err.Error(), tt.reason)
+				assert.Zero(t, client.callCount())
+			})
+		}
+	})
+
+	t.Run("accepts valid prefixes", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/my-app_v2.1/host": "localhost"})
+		for _, prefix := range []string{"/my-app_v2.1/", "my-app_v2.1", "/my-app_v2.1"} {
+			cfg, err := LoadWithLoader[Config](newLoader(client), context.Background(), prefix)
+			require.NoError(t, err, prefix)
+			assert.Equal(t, "localhost", cfg.Host)
+		}
+
+		_, err := newLoader(client).loadFromSSM(context.Background(), "/aws/service/global-infrastructure")
+		require.NoError(t, err)
+	})
+}
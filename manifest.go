@@ -0,0 +1,113 @@
+package ssmconfig
+
+import (
+	"reflect"
+	"strings"
+)
+
+// ParameterInfo describes an SSM parameter expected by a config struct.
+type ParameterInfo struct {
+	// Path is the full SSM parameter path (prefix joined with the ssm tags).
+	// Slices of structs use "{n}" as the index placeholder (e.g. "/myapp/items/{n}/name").
+	Path string
+	// Field is the dotted Go field path (e.g. "Database.Host").
+	Field string
+	// Env is the environment variable that overrides the parameter, if any.
+	Env string
+	// Required reports whether the field is marked as required.
+	Required bool
+}
+
+// ListParameters returns the SSM parameters a struct expects under the given prefix.
+// The prefix is normalized as in Load, so "myapp", "/myapp", and "/myapp/" give the same paths.
+// Nested structs are walked building the path the same way the mapper does
+// (ssm tag, or the lowercased field name when the tag is absent). Of the options, only
+// WithTagName has an effect, so keys are read from the same tag as in Load.
+// This can be used to generate Terraform or a checklist of parameters to create before deploy.
+func ListParameters[T any](prefix string, opts ...LoaderOption) []ParameterInfo {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	// Paths are built as keyPrefix + "/" + key, so the root prefix contributes nothing
+	keyPrefix := normalizePrefix(prefix)
+	if keyPrefix == "/" {
+		keyPrefix = ""
+	}
+
+	var params []ParameterInfo
+	collectParameters(t, newLoader(nil, opts...).tagName, keyPrefix, "", &params)
+	return params
+}
+
+// ListRequiredParameters returns the full paths of the required SSM parameters a struct expects.
+// Options are as in ListParameters.
+func ListRequiredParameters[T any](prefix string, opts ...LoaderOption) []string {
+	var paths []string
+	for _, param := range ListParameters[T](prefix, opts...) {
+		if param.Required {
+			paths = append(paths, param.Path)
+		}
+	}
+	return paths
+}
+
+// collectParameters walks a struct type and appends the parameters it expects.
+// Keys are read from the tagName tag ("ssm" if empty).
+func collectParameters(t reflect.Type, tagName, keyPrefix, fieldPrefix string, params *[]ParameterInfo) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // Skip unexported fields
+		}
+
+		ssmTag, squash := parseSSMTag(field, tagName)
+		jsonTag := field.Tag.Get("json")
+		isJSON := jsonTag == jsonTagTrue || jsonTag == jsonTagOne || jsonTag == jsonTagYes
+
+		fieldPath := field.Name
+		if fieldPrefix != "" {
+			fieldPath = fieldPrefix + "." + field.Name
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		if fieldType.Kind() == reflect.Struct && !isJSON && !hasDecoder(field.Type) {
+			if squash {
+				collectParameters(fieldType, tagName, keyPrefix, fieldPath, params)
+				continue
+			}
+			nestedKey := ssmTag
+			if nestedKey == "" {
+				nestedKey = strings.ToLower(field.Name)
+			}
+			collectParameters(fieldType, tagName, keyPrefix+"/"+nestedKey, fieldPath, params)
+			continue
+		}
+
+		if ssmTag == "" {
+			continue
+		}
+
+		if isStructSlice(field.Type) && !isJSON && !hasDecoder(field.Type) {
+			elemType := field.Type.Elem()
+			if elemType.Kind() == reflect.Ptr {
+				elemType = elemType.Elem()
+			}
+			collectParameters(elemType, tagName, keyPrefix+"/"+ssmTag+"/{n}", fieldPath+"[{n}]", params)
+			continue
+		}
+
+		*params = append(*params, ParameterInfo{
+			Path:     keyPrefix + "/" + ssmTag,
+			Field:    fieldPath,
+			Env:      field.Tag.Get("env"),
+			Required: isRequiredField(field.Tag.Get("required")),
+		})
+	}
+}
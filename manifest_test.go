@@ -0,0 +1,82 @@
+package ssmconfig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListParameters(t *testing.T) {
+	type Item struct {
+		Name string `ssm:"name" required:"true"`
+	}
+	type Database struct {
+		Host string `ssm:"host" env:"DB_HOST" required:"true"`
+		Port int    `ssm:"port"`
+	}
+	type Config struct {
+		DatabaseURL string   `ssm:"database_url" required:"true"`
+		Debug       bool     `ssm:"debug"`
+		Database    Database `ssm:"database"`
+		Cache       struct {
+			TTL int `ssm:"ttl" required:"1"`
+		}
+		Items   []Item   `ssm:"items"`
+		Tags    []string `ssm:"tags"`
+		EnvOnly string   `env:"ENV_ONLY"`
+	}
+
+	t.Run("lists all parameters with full paths", func(t *testing.T) {
+		params := ListParameters[Config]("/myapp/")
+		assert.Equal(t, []ParameterInfo{
+			{Path: "/myapp/database_url", Field: "DatabaseURL", Required: true},
+			{Path: "/myapp/debug", Field: "Debug"},
+			{Path: "/myapp/database/host", Field: "Database.Host", Env: "DB_HOST", Required: true},
+			{Path: "/myapp/database/port", Field: "Database.Port"},
+			{Path: "/myapp/cache/ttl", Field: "Cache.TTL", Required: true},
+			{Path: "/myapp/items/{n}/name", Field: "Items[{n}].Name", Required: true},
+			{Path: "/myapp/tags", Field: "Tags"},
+		}, params)
+	})
+
+	t.Run("lists only required parameter paths", func(t *testing.T) {
+		paths := ListRequiredParameters[Config]("/myapp")
+		assert.Equal(t, []string{
+			"/myapp/database_url",
+			"/myapp/database/host",
+			"/myapp/cache/ttl",
+			"/myapp/items/{n}/name",
+		}, paths)
+	})
+
+	t.Run("normalizes the prefix like Load", func(t *testing.T) {
+		expected := ListRequiredParameters[Config]("/myapp/")
+		assert.Equal(t, expected, ListRequiredParameters[Config]("myapp"))
+		assert.Equal(t, expected, ListRequiredParameters[Config]("myapp/"))
+
+		assert.Equal(t, []string{
+			"/database_url",
+			"/database/host",
+			"/cache/ttl",
+			"/items/{n}/name",
+		}, ListRequiredParameters[Config]("/"))
+	})
+
+	t.Run("reads keys from the WithTagName tag", func(t *testing.T) {
+		type Tagged struct {
+			Host     string `cfg:"host" required:"true"`
+			Database struct {
+				Port int `cfg:"port"`
+			} `cfg:"db"`
+		}
+		assert.Equal(t, []ParameterInfo{
+			{Path: "/myapp/host", Field: "Host", Required: true},
+			{Path: "/myapp/db/port", Field: "Database.Port"},
+		}, ListParameters[Tagged]("/myapp/", WithTagName("cfg")))
+		assert.Equal(t, []string{"/myapp/host"}, ListRequiredParameters[Tagged]("/myapp/", WithTagName("cfg")))
+	})
+
+	t.Run("returns nil for non-struct types", func(t *testing.T) {
+		assert.Nil(t, ListParameters[int]("/myapp/"))
+	})
+}
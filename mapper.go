@@ -2,79 +2,288 @@ package ssmconfig
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"reflect"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 )
 
-//nolint:gocyclo,funlen,lll // Complex function due to reflection-based mapping with multiple features
+// mapOptions controls how values are mapped into a struct.
+type mapOptions struct {
+	strict          bool
+	strictError     bool // If true, strict-mode failures are returned as errors instead of panicking
+	logger          func(format string, args ...interface{})
+	useStrongTyping bool
+	collectErrors   bool                          // If true, keep mapping after field errors and return them together
+	onFieldError    func(field string, err error) // Called for each field that fails
+	lenientBool     bool                          // If true, accept yes/no, on/off, enabled/disabled for bools
+	jsonNameKeys    bool                          // If true, untagged fields use their json tag name as the SSM key
+	keyStrategy     KeyStrategy                   // Derives SSM keys from field names for untagged fields
+	strictJSON      bool                          // If true, unknown JSON object keys are decoding errors
+	lenientJSON     bool                          // If true, string fields take a non-JSON value literally
+	interpolate     bool                          // If true, ${ENV_VAR} references in values are expanded
+	strictInterp    bool                          // If true, undefined ${ENV_VAR} references are errors
+	parameterTypes  map[string]string             // SSM types of list parameters (e.g. StringList) by key
+	tagName         string                        // Struct tag holding the SSM key ("ssm" if empty)
+	fsys            fs.FS                         // Filesystem for fromfile fields (local disk if nil)
+	fileEnv         bool                          // If true, FOO_FILE names a file holding the value of env var FOO
+	valueSources    map[string]string             // Source (file or ssm) of each value by key, for Explain
+	listNested      bool                          // If true, a missing required struct also lists its required fields
+	foldCase        bool                          // If true, keys are lowercased to match the case-folded values
+	groups          []string                      // If set, only fields in these groups (and ungrouped ones) are mapped
+	unsetSentinel   string                        // If set, a value equal to it leaves the field unset
+	strictGroups    bool                          // If true, ungrouped fields are skipped when groups are selected
+	envPrefix       string                        // Prepended to every env tag name (see WithGlobalPrefix)
+}
+
+// mapper holds the state of a single mapping run.
+type mapper struct {
+	opts           *mapOptions
+	errors         []error           // Field errors collected in collect-errors mode
+	parameterTypes map[string]string // SSM parameter types keyed relative to the struct being mapped
+	rootValues     map[string]string // All loaded values, for inparam allowlists
+	valueSources   map[string]string // Value sources keyed relative to the struct being mapped
+	keyPrefix      string            // Full SSM key of the struct being mapped, for reports
+	explain        bool              // If true, a report entry is recorded per field
+	reports        []FieldReport     // Field reports recorded in explain mode
+	labels         map[string]string // Field labels by field path, recorded in explain mode
+	grouped        bool              // If true, the struct being mapped was selected by its group tag
+	validators     *validatorSet     // Validators registered when the load started
+	conditional    []whenCheck       // Validations deferred by when tags in the struct being mapped
+}
+
+//nolint:lll // Signature kept for callers that don't need the extended options
 func mapToStruct(values map[string]string, dest interface{}, strict bool, logger func(format string, args ...interface{}), useStrongTyping bool) error {
+	return mapToStructWithOptions(values, dest, &mapOptions{
+		strict:          strict,
+		logger:          logger,
+		useStrongTyping: useStrongTyping,
+	})
+}
+
+// mapToStructWithOptions maps values into dest.
+// In collect-errors mode all field errors are returned together; otherwise mapping stops at the first error.
+// A strict-mode failure panics with its message, or is returned as a *MissingRequiredError if
+// opts.strictError is set.
+func mapToStructWithOptions(values map[string]string, dest interface{}, opts *mapOptions) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			failure, ok := r.(strictModeError)
+			if !ok {
+				// Other panics (a nil dereference, a panicking decoder or validator) are bugs
+				panic(r)
+			}
+			if !opts.strictError {
+				panic(failure.Error())
+			}
+			err = failure.err
+		}
+	}()
+
+	m := &mapper{opts: opts, parameterTypes: opts.parameterTypes, rootValues: values, validators: snapshotValidators()}
+	if err := m.mapStruct(values, dest, ""); err != nil {
+		return err
+	}
+	if len(m.errors) > 0 {
+		return errors.Join(m.errors...)
+	}
+	return nil
+}
+
+// fieldError reports a field error. In collect-errors mode the error is recorded and nil
+// is returned so mapping continues; otherwise the error is returned to stop mapping.
+func (m *mapper) fieldError(fieldPath string, err error) error {
+	m.recordFailure(fieldPath, err.Error())
+	if m.opts.onFieldError != nil {
+		m.opts.onFieldError(fieldPath, err)
+	}
+	if m.opts.collectErrors {
+		m.errors = append(m.errors, err)
+		return nil
+	}
+	return err
+}
+
+// checkAllowlist checks the field against the allowlist named by its inparam tag.
+// A failure is reported like a validation error.
+func (m *mapper) checkAllowlist(fv reflect.Value, inParamTag, fieldName, fieldPath string) error {
+	if inParamTag == "" {
+		return nil
+	}
+	if err := checkInParam(fv, m.canonicalKey(inParamTag), m.rootValues, fieldName); err != nil {
+		return m.fieldError(fieldPath, err)
+	}
+	return nil
+}
+
+// validate runs the validators from the field's validate tag. A failure is reported like any
+// other field error, so in collect-errors mode it is recorded and mapping continues. With a
+// when tag the validation is deferred until the whole struct is set (see runConditional).
+func (m *mapper) validate(fv reflect.Value, meta *fieldMeta, fieldPath string) error {
+	if meta.validateTag == "" {
+		return nil
+	}
+	if meta.whenTag != "" {
+		m.conditional = append(m.conditional, whenCheck{
+			fv:          fv,
+			validateTag: meta.validateTag,
+			whenTag:     meta.whenTag,
+			fieldName:   meta.label,
+			fieldPath:   fieldPath,
+		})
+		return nil
+	}
+	if err := m.validators.validateField(fv, meta.validateTag, meta.label, m.opts.fsys); err != nil {
+		return m.fieldError(fieldPath, err)
+	}
+	return nil
+}
+
+//nolint:gocyclo,funlen // Complex function due to reflection-based mapping with multiple features
+func (m *mapper) mapStruct(values map[string]string, dest interface{}, path string) error {
 	v := reflect.ValueOf(dest)
 	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
 		return fmt.Errorf("dest must be a pointer to struct")
 	}
 
 	v = v.Elem()
-	t := v.Type()
 
 	var missingRequired []string
 
-	for i := 0; i < v.NumField(); i++ {
-		field := t.Field(i)
-		ssmTag := field.Tag.Get("ssm")
-		envTag := field.Tag.Get("env")
-		requiredTag := field.Tag.Get("required")
-		jsonTag := field.Tag.Get("json")
-		validateTag := field.Tag.Get("validate")
+	// Validations deferred by when tags are collected per struct
+	parentConditional := m.conditional
+	m.conditional = nil
+	defer func() { m.conditional = parentConditional }()
+
+	// Tags are parsed once per struct type; see structFields
+	for i, meta := range structFields(v.Type(), m.opts.tagName) {
+		field := meta.field
+		ssmTag, squash, envTag := meta.ssmTag, meta.squash, meta.envTag
+		if ssmTag == "" && envTag == "" {
+			ssmTag = m.implicitKey(field)
+		}
+		ssmTag = m.canonicalKey(ssmTag)
+		requiredTag := meta.requiredTag
+		jsonTag := meta.jsonTag
+		transformTag := meta.transformTag
+		encodingTag := meta.encodingTag
+		fromFile := meta.fromFile
+		inParamTag := meta.inParamTag
+		defaultTag := meta.defaultTag
 
 		fv := v.Field(i)
 		if !fv.CanSet() {
 			continue
 		}
+		// Fields outside the groups selected with WithGroups are neither mapped nor validated
+		if !m.inGroups(field, m.grouped) {
+			continue
+		}
 
-		// Handle nested structs (with or without tags)
-		fieldType := field.Type
-		if fieldType.Kind() == reflect.Ptr {
-			fieldType = fieldType.Elem()
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+		// Messages and reports name the field by its label tag, if any
+		fieldName := meta.label
+		m.recordLabel(fieldPath, fieldName)
+
+		// The source tag (e.g. source:"ssm,env") overrides the default ENV > SSM lookup order
+		sources := meta.sources
+		if meta.sourcesErr != nil {
+			if ferr := m.fieldError(fieldPath, meta.sourcesErr); ferr != nil {
+				return ferr
+			}
+			continue
 		}
 
-		if fieldType.Kind() == reflect.Struct {
+		// Handle nested structs (with or without tags)
+		fieldType := meta.fieldType
+
+		// Struct types with a registered decoder are parsed like regular fields
+		if fieldType.Kind() == reflect.Struct && !hasDecoder(field.Type) {
+			// A gated struct (gate:"features/redis_enabled") is skipped entirely, including its
+			// required checks, unless the gate key holds a true value
+			if meta.gateTag != "" && !m.gateOpen(values, meta.gateTag) {
+				continue
+			}
+
 			// Check if this nested struct should be decoded from JSON
 			if jsonTag == jsonTagTrue || jsonTag == jsonTagOne || jsonTag == jsonTagYes {
-				// Decode nested struct from JSON string
-				var val string
-				var hasValue bool
-
-				// Check environment variable first (override)
-				if envTag != "" {
-					val = os.Getenv(envTag)
-					if val != "" {
-						hasValue = true
+				// Decode nested struct from JSON string, checking sources in order
+				// (environment variable first by default). Whitespace-only values are treated as not present.
+				val, hasValue, fromValues, err := m.lookupValue(sources, values, ssmTag, envTag, fieldName, nonBlank)
+				if err != nil {
+					if ferr := m.fieldError(fieldPath, err); ferr != nil {
+						return ferr
 					}
+					continue
 				}
-
-				// Fall back to SSM parameter if env var not set or empty
-				if !hasValue && ssmTag != "" {
-					if ssmVal, exists := values[ssmTag]; exists && ssmVal != "" {
-						val = ssmVal
-						hasValue = true
+				source := m.valueSource(ssmTag, hasValue, fromValues)
+
+				// A fromfile field holds a path; the JSON document is the file's contents
+				if hasValue && fromFile {
+					contents, err := readValueFile(m.opts.fsys, val, fieldName)
+					if err != nil {
+						if ferr := m.fieldError(fieldPath, err); ferr != nil {
+							return ferr
+						}
+						continue
 					}
+					val = contents
+					hasValue = strings.TrimSpace(val) != ""
 				}
+				switch {
+				case hasValue && m.isUnset(val):
+					val, hasValue, source = "", false, SourceNone
+				case !hasValue && defaultTag != "":
+					val, hasValue, source = defaultTag, true, SourceDefault
+				case !hasValue:
+					source = SourceNone
+				}
+				m.record(field, fieldPath, ssmTag, envTag, source)
 
 				// Only validate required fields - skip optional fields silently
 				if !hasValue {
 					if isRequiredField(requiredTag) {
-						missingInfo := fmt.Sprintf("field '%s' (ssm:'%s', env:'%s')", field.Name, ssmTag, envTag)
+						m.recordFailure(fieldPath, missingValueReason)
+						missingInfo := missingFieldInfo(field, "field", ssmTag, envTag)
 						missingRequired = append(missingRequired, missingInfo)
-						if logger != nil {
-							logger("WARNING: Required field missing: %s", missingInfo)
+						if m.opts.logger != nil {
+							m.opts.logger("WARNING: Required field missing: %s", missingInfo)
 						}
 					}
 					continue
 				}
 
+				// Undo value encoding (e.g. encoding:"gzip+base64") before decoding JSON
+				decoded, err := decodeValue(val, encodingTag, fieldName)
+				if err != nil {
+					if ferr := m.fieldError(fieldPath, err); ferr != nil {
+						return ferr
+					}
+					continue
+				}
+				val = decoded
+
+				// Expand ${ENV_VAR} references before decoding JSON
+				if m.opts.interpolate {
+					expanded, err := interpolateEnv(val, fieldName, m.opts.strictInterp)
+					if err != nil {
+						if ferr := m.fieldError(fieldPath, err); ferr != nil {
+							return ferr
+						}
+						continue
+					}
+					val = expanded
+				}
+
 				// Decode JSON into nested struct
 				var nestedPtr interface{}
 				if fv.Kind() == reflect.Ptr {
@@ -82,24 +291,21 @@ func mapToStruct(values map[string]string, dest interface{}, strict bool, logger
 						fv.Set(reflect.New(fieldType))
 					}
 					nestedPtr = fv.Interface()
-					// For pointer, decode directly
-					if err := json.Unmarshal([]byte(val), nestedPtr); err != nil {
-						return fmt.Errorf("decoding JSON for nested struct field %s: %w", field.Name, err)
-					}
 				} else {
 					// For value type, decode into address
 					nestedPtr = fv.Addr().Interface()
-					if err := json.Unmarshal([]byte(val), nestedPtr); err != nil {
-						return fmt.Errorf("decoding JSON for nested struct field %s: %w", field.Name, err)
+				}
+				if err := decodeJSON(val, nestedPtr, m.opts); err != nil {
+					err = fmt.Errorf("decoding JSON for nested struct field %s: %w", fieldName, err)
+					if ferr := m.fieldError(fieldPath, err); ferr != nil {
+						return ferr
 					}
+					continue
 				}
 
 				// Run custom validators for nested struct if specified
-				if validateTag != "" {
-					ensureBuiltinValidators() // Ensure built-in validators are available
-					if err := validateField(fv, validateTag, field.Name); err != nil {
-						return err
-					}
+				if err := m.validate(fv, &meta, fieldPath); err != nil {
+					return err
 				}
 				continue
 			}
@@ -126,34 +332,99 @@ func mapToStruct(values map[string]string, dest interface{}, strict bool, logger
 				prefix = strings.ToLower(field.Name)
 			}
 
-			// Filter values with the prefix for nested struct
-			nestedValues := filterValuesByPrefix(values, prefix)
+			// Filter values with the prefix for nested struct.
+			// Squashed structs see the parent's values, so their fields map at the parent level.
+			nestedValues := values
+			if !squash {
+				nestedValues = filterValuesByPrefix(values, prefix)
+			}
 
 			// Check if nested struct itself is required
 			isNestedRequired := isRequiredField(requiredTag)
 
-			// If nested struct is required, check if it has any values
-			if isNestedRequired && len(nestedValues) == 0 {
-				missingInfo := fmt.Sprintf("nested struct field '%s' (ssm:'%s', env:'%s')", field.Name, ssmTag, envTag)
+			// If nested struct is required, check if it has any values (env overrides of its fields count)
+			if isNestedRequired && len(nestedValues) == 0 && !m.hasEnvValue(fieldType, nil) {
+				m.record(field, fieldPath, "", envTag, SourceNone)
+				m.recordFailure(fieldPath, missingValueReason)
+				missingInfo := missingFieldInfo(field, "nested struct field", ssmTag, envTag)
 				missingRequired = append(missingRequired, missingInfo)
-				if logger != nil {
-					logger("WARNING: Required nested struct missing: %s", missingInfo)
+				if m.opts.logger != nil {
+					m.opts.logger("WARNING: Required nested struct missing: %s", missingInfo)
+				}
+				if m.opts.listNested {
+					keyPrefix := prefix
+					if squash {
+						keyPrefix = ""
+					}
+					innerFields := m.missingNestedFields(fieldType, keyPrefix, fieldPath, groupedBy(field, m.grouped), nil)
+					for _, innerInfo := range innerFields {
+						missingRequired = append(missingRequired, innerInfo)
+						if m.opts.logger != nil {
+							m.opts.logger("WARNING: Required field missing: %s", innerInfo)
+						}
+					}
 				}
 				continue
 			}
 
-			if err := mapToStruct(nestedValues, nestedPtr, strict, logger, useStrongTyping); err != nil {
-				return fmt.Errorf("mapping nested struct field %s: %w", field.Name, err)
+			parameterTypes, valueSources, keyPrefix, grouped := m.parameterTypes, m.valueSources, m.keyPrefix, m.grouped
+			if !squash {
+				m.parameterTypes = filterValuesByPrefix(parameterTypes, prefix)
+				m.valueSources = filterValuesByPrefix(valueSources, prefix)
+				m.keyPrefix = joinKey(keyPrefix, prefix)
+			}
+			m.grouped = groupedBy(field, grouped)
+			err := m.mapStruct(nestedValues, nestedPtr, fieldPath)
+			m.parameterTypes, m.valueSources, m.keyPrefix, m.grouped = parameterTypes, valueSources, keyPrefix, grouped
+			if err != nil {
+				return fmt.Errorf("mapping nested struct field %s: %w", fieldName, err)
 			}
 
 			// Run custom validators for nested struct if specified
-			if validateTag != "" {
-				ensureBuiltinValidators() // Ensure built-in validators are available
-				if err := validateField(fv, validateTag, field.Name); err != nil {
+			if err := m.validate(fv, &meta, fieldPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Slices of structs can be reconstructed from indexed keys (e.g. "items/0/name").
+		// An env override or a json:"true" tag keeps the regular single-value handling.
+		if ssmTag != "" && isStructSlice(field.Type) && !hasDecoder(field.Type) &&
+			jsonTag != jsonTagTrue && jsonTag != jsonTagOne && jsonTag != jsonTagYes &&
+			!m.envOverrides(sources, envTag) {
+			if indices, elements := groupIndexedValues(values, ssmTag); len(elements) > 0 {
+				grouped := m.grouped
+				m.grouped = groupedBy(field, grouped)
+				err := m.setStructSlice(fv, indices, elements, ssmTag, fieldPath)
+				m.grouped = grouped
+				if err != nil {
+					return fmt.Errorf("mapping slice field %s: %w", fieldName, err)
+				}
+
+				// Run custom validators if specified
+				if err := m.validate(fv, &meta, fieldPath); err != nil {
 					return err
 				}
+				continue
+			}
+		}
+
+		// Maps can be reconstructed from sub-parameters ("labels/team" sets Labels["team"]) when
+		// no single (e.g. JSON) value is stored under the key itself. An env override wins.
+		if ssmTag != "" && isStringKeyMap(field.Type) && !hasDecoder(field.Type) && !m.envOverrides(sources, envTag) {
+			if sub := subKeyValues(values, ssmTag); len(sub) > 0 {
+				m.record(field, fieldPath, ssmTag, envTag, m.subKeySource(ssmTag, sub))
+				if err := setSubKeyMap(fv, sub, fieldName); err != nil {
+					if ferr := m.fieldError(fieldPath, err); ferr != nil {
+						return ferr
+					}
+					continue
+				}
+				if err := m.validate(fv, &meta, fieldPath); err != nil {
+					return err
+				}
+				continue
 			}
-			continue
 		}
 
 		// Handle regular (non-struct) fields
@@ -163,100 +434,228 @@ func mapToStruct(values map[string]string, dest interface{}, strict bool, logger
 
 		isRequired := isRequiredField(requiredTag)
 
-		var val string
-		var hasValue bool
-
-		// Priority 1: Check environment variable first (highest priority)
-		if envTag != "" {
-			val = os.Getenv(envTag)
-			if val != "" {
-				hasValue = true
+		// Default priority: ENV > File > SSM, unless the source tag says otherwise.
+		// Note: values map contains both SSM and file values (file values override SSM)
+		val, hasValue, fromValues, err := m.lookupValue(sources, values, ssmTag, envTag, fieldName, nonEmpty)
+		if err != nil {
+			if ferr := m.fieldError(fieldPath, err); ferr != nil {
+				return ferr
 			}
+			continue
 		}
-
-		// Priority 2: Check file-based config (middle priority)
-		// File values are already merged into values map, but we check them here
-		// to maintain explicit priority: ENV > File > SSM
-		// Since file values are merged into values, we need to distinguish them
-		// For now, we'll check values map which contains both SSM and file values
-		// The file values will be checked before pure SSM values in the next step
-
-		// Priority 3: Fall back to SSM parameter or file value (lowest priority)
-		// Note: values map now contains both SSM and file values (file values override SSM)
-		if !hasValue && ssmTag != "" {
-			if ssmVal, exists := values[ssmTag]; exists && ssmVal != "" {
-				val = ssmVal
-				hasValue = true
+		source := m.valueSource(ssmTag, hasValue, fromValues)
+
+		// A fromfile field holds a path (e.g. a mounted secret); the value is the file's contents
+		if hasValue && fromFile {
+			contents, err := readValueFile(m.opts.fsys, val, fieldName)
+			if err != nil {
+				if ferr := m.fieldError(fieldPath, err); ferr != nil {
+					return ferr
+				}
+				continue
 			}
+			val = contents
+			hasValue = val != ""
+			fromValues = false
+		}
+		// The unset sentinel clears the field, default included; otherwise the default tag
+		// is the last resort, after every source in the lookup order
+		switch {
+		case hasValue && m.isUnset(val):
+			val, hasValue, source = "", false, SourceNone
+		case !hasValue && defaultTag != "":
+			val, hasValue, source = defaultTag, true, SourceDefault
+		case !hasValue:
+			source = SourceNone
 		}
+		m.record(field, fieldPath, ssmTag, envTag, source)
 
 		// Only validate required fields - skip optional fields silently
 		if !hasValue {
 			if isRequired {
-				missingInfo := fmt.Sprintf("field '%s' (ssm:'%s', env:'%s')", field.Name, ssmTag, envTag)
+				m.recordFailure(fieldPath, missingValueReason)
+				missingInfo := missingFieldInfo(field, "field", ssmTag, envTag)
 				missingRequired = append(missingRequired, missingInfo)
-				if logger != nil {
-					logger("WARNING: Required field missing: %s", missingInfo)
+				if m.opts.logger != nil {
+					m.opts.logger("WARNING: Required field missing: %s", missingInfo)
 				}
 			}
 			continue
 		}
 
+		// Undo value encoding (e.g. encoding:"gzip+base64") before transforms and conversion
+		if encodingTag != "" {
+			decoded, err := decodeValue(val, encodingTag, fieldName)
+			if err != nil {
+				if ferr := m.fieldError(fieldPath, err); ferr != nil {
+					return ferr
+				}
+				continue
+			}
+			val = decoded
+		}
+
+		// Expand ${ENV_VAR} references before transforms and conversion
+		if m.opts.interpolate {
+			expanded, err := interpolateEnv(val, fieldName, m.opts.strictInterp)
+			if err != nil {
+				if ferr := m.fieldError(fieldPath, err); ferr != nil {
+					return ferr
+				}
+				continue
+			}
+			val = expanded
+		}
+
+		// Apply transforms (e.g. transform:"lower,trimslash") before conversion
+		if transformTag != "" {
+			transformed, err := applyTransforms(val, transformTag, fieldName)
+			if err != nil {
+				if ferr := m.fieldError(fieldPath, err); ferr != nil {
+					return ferr
+				}
+				continue
+			}
+			val = transformed
+		}
+
+		// StringList parameters are always mapped as lists, regardless of the json tag
+		if fromValues && m.parameterTypes[ssmTag] == parameterTypeStringList {
+			if err := setStringList(fv, val, fieldName, ssmTag); err != nil {
+				if ferr := m.fieldError(fieldPath, err); ferr != nil {
+					return ferr
+				}
+				continue
+			}
+			if err := m.checkAllowlist(fv, inParamTag, fieldName, fieldPath); err != nil {
+				return err
+			}
+			if err := m.validate(fv, &meta, fieldPath); err != nil {
+				return err
+			}
+			continue
+		}
+
 		// Determine whether to use JSON decoding or strongly-typed conversion
 		// Priority: json tag > loader preference
 		useJSON := jsonTag == jsonTagTrue || jsonTag == jsonTagOne || jsonTag == jsonTagYes
 
 		if !useJSON {
-			// No explicit JSON tag - use loader's preference
-			useJSON = !useStrongTyping
+			// No explicit JSON tag - use loader's preference (registered decoders always win)
+			useJSON = !m.opts.useStrongTyping && !hasDecoder(field.Type)
+		}
+
+		// Interface fields with a polymorphic registration are always decoded from JSON
+		polymorphic, isPolymorphic := getPolymorphic(ssmTag)
+		isPolymorphic = isPolymorphic && fv.Kind() == reflect.Interface
+		if isPolymorphic {
+			useJSON = true
 		}
 
-		if useJSON {
+		// A whitespace-only JSON value is treated as not present, mirroring the strongly-typed path
+		if useJSON && strings.TrimSpace(val) == "" {
+			if isRequired {
+				m.recordFailure(fieldPath, missingValueReason)
+				missingInfo := missingFieldInfo(field, "field", ssmTag, envTag)
+				missingRequired = append(missingRequired, missingInfo)
+				if m.opts.logger != nil {
+					m.opts.logger("WARNING: Required field missing: %s", missingInfo)
+				}
+			}
+			continue
+		}
+
+		var setErr error
+		if isPolymorphic {
+			if err := polymorphic.decode(fv, val, m.opts); err != nil {
+				setErr = fmt.Errorf("decoding polymorphic field %s: %w", fieldName, err)
+			}
+		} else if useJSON {
 			// Use JSON decoding - requires valid JSON format
-			if err := setFieldValueJSON(fv, val); err != nil {
-				return fmt.Errorf("decoding JSON for field %s: %w", field.Name, err)
+			if err := setFieldValueJSONWithOptions(fv, val, m.opts); err != nil {
+				setErr = fmt.Errorf("decoding JSON for field %s: %w", fieldName, err)
 			}
 		} else {
 			// Use strongly typed conversion for simple types
 			// For complex types (non-string slices, maps), JSON decoding is required
-			if err := setFieldValue(fv, val); err != nil {
+			if m.opts.lenientBool && fv.Kind() == reflect.Bool {
+				val = normalizeBool(val)
+			}
+			// The base tag (e.g. base:"16" or base:"0" to honor 0x/0o/0b prefixes) applies to integers
+			if meta.baseErr != nil {
+				setErr = meta.baseErr
+			} else if err := setFieldValueWithBase(fv, val, meta.base); err != nil {
 				// If strongly typed conversion fails and it's a complex type,
 				// suggest using json:"true" tag or setting useStrongTyping=false
 				kind := fv.Kind()
-				if kind == reflect.Slice && fv.Type().Elem().Kind() != reflect.String {
-					return fmt.Errorf("setting field %s: %w (hint: use json:\"true\" tag or "+
-						"set useStrongTyping=false)", field.Name, err)
-				}
-				if kind == reflect.Map {
-					return fmt.Errorf("setting field %s: %w (hint: use json:\"true\" tag or "+
-						"set useStrongTyping=false)", field.Name, err)
+				if (kind == reflect.Slice && fv.Type().Elem().Kind() != reflect.String) || kind == reflect.Map {
+					setErr = fmt.Errorf("setting field %s: %w (hint: use json:\"true\" tag or "+
+						"set useStrongTyping=false)", fieldName, err)
+				} else {
+					setErr = fmt.Errorf("setting field %s: %w", fieldName, err)
 				}
-				return fmt.Errorf("setting field %s: %w", field.Name, err)
 			}
 		}
-
-		// Run custom validators if specified
-		if validateTag != "" {
-			ensureBuiltinValidators() // Ensure built-in validators are available
-			if err := validateField(fv, validateTag, field.Name); err != nil {
-				return err
+		if setErr != nil {
+			if ferr := m.fieldError(fieldPath, setErr); ferr != nil {
+				return ferr
 			}
+			continue
+		}
+
+		// Check the inparam allowlist, then run custom validators if specified
+		if err := m.checkAllowlist(fv, inParamTag, fieldName, fieldPath); err != nil {
+			return err
+		}
+		if err := m.validate(fv, &meta, fieldPath); err != nil {
+			return err
 		}
 	}
 
+	// Conditional validators run once every field is set, so a when tag can name any sibling
+	if err := m.runConditional(v, m.conditional); err != nil {
+		return err
+	}
+
 	// Validate and report missing required fields
-	if len(missingRequired) > 0 {
-		msg := fmt.Sprintf("Missing required fields: %s", strings.Join(missingRequired, ", "))
-		if strict {
-			panic(fmt.Sprintf("ssmconfig: %s", msg))
-		}
-		// In non-strict mode, we still log but don't panic
-		// The error is already logged per field above
+	if len(missingRequired) > 0 && m.opts.strict {
+		panic(strictModeError{err: &MissingRequiredError{Fields: missingRequired}})
 	}
+	// In non-strict mode, we still log but don't panic
+	// The error is already logged per field above
 
 	return nil
 }
 
+// MissingRequiredError is returned by a strict-mode load when required fields are missing.
+// It wraps ErrMissingRequired.
+type MissingRequiredError struct {
+	// Fields describes each missing field (its requiredmsg, if set).
+	Fields []string
+}
+
+func (e *MissingRequiredError) Error() string {
+	return "ssmconfig: Missing required fields: " + strings.Join(e.Fields, ", ")
+}
+
+func (e *MissingRequiredError) Unwrap() error {
+	return ErrMissingRequired
+}
+
+// strictModeError unwinds a strict-mode failure out of nested mapStruct calls to
+// mapToStructWithOptions, which tells it apart from unrelated panics.
+type strictModeError struct {
+	err *MissingRequiredError
+}
+
+func (e strictModeError) Error() string {
+	return e.err.Error()
+}
+
+func (e strictModeError) Unwrap() error {
+	return e.err
+}
+
 // ValidateRequiredFields validates that all required fields are present.
 // This can be called separately to check validation without loading.
 // Returns an error listing all missing required fields.
@@ -285,10 +684,10 @@ func ValidateRequiredFields[T any](values map[string]string, logger func(format
 			continue
 		}
 
-		// Check if value exists
-		hasValue := false
-		if envTag != "" {
-			if os.Getenv(envTag) != "" {
+		// Check if value exists; a default tag always provides one
+		hasValue := field.Tag.Get("default") != ""
+		for _, name := range envNames(envTag) {
+			if os.Getenv(name) != "" {
 				hasValue = true
 			}
 		}
@@ -299,7 +698,7 @@ func ValidateRequiredFields[T any](values map[string]string, logger func(format
 		}
 
 		if !hasValue {
-			missingInfo := fmt.Sprintf("field '%s' (ssm:'%s', env:'%s')", field.Name, ssmTag, envTag)
+			missingInfo := missingFieldInfo(field, "field", ssmTag, envTag)
 			missingRequired = append(missingRequired, missingInfo)
 			if logger != nil {
 				logger("WARNING: Required field missing: %s", missingInfo)
@@ -314,8 +713,106 @@ func ValidateRequiredFields[T any](values map[string]string, logger func(format
 	return nil
 }
 
+// parseBaseTag returns the integer base named by a base tag: 0 (inferred from the prefix) or 2-36.
+// An empty tag yields base 10.
+func parseBaseTag(tag, fieldName string) (int, error) {
+	if tag == "" {
+		return 10, nil
+	}
+	base, err := strconv.Atoi(strings.TrimSpace(tag))
+	if err != nil || base == 1 || base < 0 || base > 36 {
+		return 0, fmt.Errorf("invalid base '%s' for field %s (want 0 or 2-36)", tag, fieldName)
+	}
+	return base, nil
+}
+
+// lenientBoolValues maps common boolean spellings to values accepted by strconv.ParseBool.
+var lenientBoolValues = map[string]string{
+	"yes":      "true",
+	"on":       "true",
+	"enabled":  "true",
+	"no":       "false",
+	"off":      "false",
+	"disabled": "false",
+}
+
+// normalizeBool converts lenient boolean spellings (case-insensitive) to "true" or "false".
+// Unknown values are returned unchanged so strconv.ParseBool can report them.
+func normalizeBool(val string) string {
+	if normalized, ok := lenientBoolValues[strings.ToLower(strings.TrimSpace(val))]; ok {
+		return normalized
+	}
+	return val
+}
+
+// canonicalKey returns the key as it appears in the values: lowercased with case-insensitive
+// keys (WithCaseInsensitiveKeys), unchanged otherwise.
+func (m *mapper) canonicalKey(key string) string {
+	if m.opts.foldCase {
+		return strings.ToLower(key)
+	}
+	return key
+}
+
+// implicitKey returns the SSM key for a field without ssm and env tags, or "" if it has none.
+func (m *mapper) implicitKey(field reflect.StructField) string {
+	if m.opts.jsonNameKeys {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		// json:"true" marks JSON decoding rather than naming the field
+		if name != "" && name != "-" && name != jsonTagTrue && name != jsonTagOne && name != jsonTagYes {
+			return name
+		}
+	}
+	return m.opts.keyStrategy.key(field.Name)
+}
+
+// defaultTagName is the struct tag holding the SSM key unless WithTagName sets another.
+const defaultTagName = "ssm"
+
+// parseSSMTag returns the ssm key of a field and whether the field is squashed.
+// The key is read from the tagName tag ("ssm" if empty).
+// A struct field is squashed with ssm:",squash" or squash:"true"; its fields are
+// then mapped at the parent level without a key prefix.
+func parseSSMTag(field reflect.StructField, tagName string) (string, bool) {
+	if tagName == "" {
+		tagName = defaultTagName
+	}
+	key, options, _ := strings.Cut(field.Tag.Get(tagName), ",")
+	squashTag := field.Tag.Get("squash")
+	squash := options == "squash" || squashTag == jsonTagTrue || squashTag == jsonTagOne || squashTag == jsonTagYes
+	return key, squash
+}
+
+// missingFieldInfo describes a missing required field for warnings and errors.
+// The requiredmsg tag, when present, replaces the default "(ssm:'...', env:'...')" details
+// so operators get actionable guidance (e.g. where to create the parameter).
+func missingFieldInfo(field reflect.StructField, kind, ssmTag, envTag string) string {
+	if msg := field.Tag.Get("requiredmsg"); msg != "" {
+		return fmt.Sprintf("%s '%s': %s", kind, fieldLabel(field), msg)
+	}
+	return fmt.Sprintf("%s '%s' (ssm:'%s', env:'%s')", kind, fieldLabel(field), ssmTag, envTag)
+}
+
+// isUnset reports whether a resolved value is the WithUnsetSentinel sentinel.
+func (m *mapper) isUnset(val string) bool {
+	return m.opts.unsetSentinel != "" && val == m.opts.unsetSentinel
+}
+
+// fieldLabel returns the name messages use for a field: its label tag (e.g. label:"Database Host")
+// or, without one, its Go field name.
+func fieldLabel(field reflect.StructField) string {
+	if label := strings.TrimSpace(field.Tag.Get("label")); label != "" {
+		return label
+	}
+	return field.Name
+}
+
+// isRequiredField reports whether a required tag holds a truthy value. Matching is
+// case-insensitive and accepts the spellings of strconv.ParseBool (true, t, 1) as well as
+// the lenient bool ones (yes, on, enabled).
 func isRequiredField(requiredTag string) bool {
-	return requiredTag == "true" || requiredTag == "1" || requiredTag == "yes"
+	required, err := strconv.ParseBool(normalizeBool(strings.ToLower(requiredTag)))
+	return err == nil && required
 }
 
 // filterValuesByPrefix filters the values map to only include keys that start with the given prefix.
@@ -344,12 +841,254 @@ func filterValuesByPrefix(values map[string]string, prefix string) map[string]st
 	return result
 }
 
+// gateOpen reports whether the gate key holds a true value. A missing or unparsable
+// value keeps the gate closed. Lenient bool spellings are accepted with WithLenientBool.
+func (m *mapper) gateOpen(values map[string]string, key string) bool {
+	val := strings.TrimSpace(values[m.canonicalKey(key)])
+	if m.opts.lenientBool {
+		val = normalizeBool(val)
+	}
+	open, err := strconv.ParseBool(val)
+	return err == nil && open
+}
+
+// hasEnvValue reports whether an environment variable named by an env tag of the struct type,
+// or of its nested structs, is set. Fields whose source tag excludes env are skipped.
+func (m *mapper) hasEnvValue(typ reflect.Type, onPath []reflect.Type) bool {
+	// A type already being walked (e.g. through a Next *Node field) has had its fields checked
+	if slices.Contains(onPath, typ) {
+		return false
+	}
+	onPath = append(onPath, typ)
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		envTag := field.Tag.Get("env")
+		sources, err := parseSourceTag(field.Tag.Get("source"), field.Name)
+		if err == nil && slices.Contains(sources, SourceEnv) && m.envSet(envTag) {
+			return true
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct && m.hasEnvValue(fieldType, onPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// missingNestedFields describes the required fields of a struct type that has no values, for
+// listing them along with the missing required struct itself. Fields are named by their path
+// (e.g. "Database.Host") and keyed relative to the parent (e.g. "database/host"). Nested
+// structs are included, except gated ones, which are skipped without values. Fields outside
+// the selected groups are left out; grouped is as in inGroups. onPath holds the struct types
+// being walked, so a self-referential type is listed once instead of recursing forever.
+func (m *mapper) missingNestedFields(typ reflect.Type, keyPrefix, path string, grouped bool,
+	onPath []reflect.Type) []string {
+	onPath = append(onPath, typ)
+	var missing []string
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || !m.inGroups(field, grouped) {
+			continue
+		}
+		ssmTag, squash := parseSSMTag(field, m.opts.tagName)
+		envTag := field.Tag.Get("env")
+		if ssmTag == "" && envTag == "" {
+			ssmTag = m.implicitKey(field)
+		}
+		ssmTag = m.canonicalKey(ssmTag)
+		jsonTag := field.Tag.Get("json")
+
+		// Name the field by its path in the messages
+		named := field
+		named.Name = path + "." + field.Name
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if fieldType.Kind() == reflect.Struct && !hasDecoder(field.Type) &&
+			jsonTag != jsonTagTrue && jsonTag != jsonTagOne && jsonTag != jsonTagYes {
+			if field.Tag.Get("gate") != "" {
+				continue
+			}
+			prefix := ssmTag
+			if prefix == "" {
+				prefix = strings.ToLower(field.Name)
+			}
+			nestedPrefix := keyPrefix
+			if !squash {
+				nestedPrefix = joinKey(keyPrefix, prefix)
+			}
+			if isRequiredField(field.Tag.Get("required")) {
+				missing = append(missing, missingFieldInfo(named, "nested struct field", joinKey(keyPrefix, ssmTag), envTag))
+			}
+			if !slices.Contains(onPath, fieldType) {
+				missing = append(missing,
+					m.missingNestedFields(fieldType, nestedPrefix, named.Name, groupedBy(field, grouped), onPath)...)
+			}
+			continue
+		}
+
+		if isRequiredField(field.Tag.Get("required")) && field.Tag.Get("default") == "" {
+			key := ""
+			if ssmTag != "" {
+				key = joinKey(keyPrefix, ssmTag)
+			}
+			missing = append(missing, missingFieldInfo(named, "field", key, envTag))
+		}
+	}
+	return missing
+}
+
+// isStructSlice reports whether the type is a slice of structs or of pointers to structs.
+func isStructSlice(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Slice {
+		return false
+	}
+	elem := typ.Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	return elem.Kind() == reflect.Struct
+}
+
+// groupIndexedValues groups keys like "prefix/0/name" by their index.
+// The returned groups are ordered by index; missing indices are skipped, so sparse
+// indices (e.g. 0, 2, 5) produce a compact list of three elements. The original
+// index of each group is returned alongside it.
+func groupIndexedValues(values map[string]string, prefix string) ([]int, []map[string]string) {
+	groups := make(map[int]map[string]string)
+	for key, value := range filterValuesByPrefix(values, prefix) {
+		indexPart, rest, found := strings.Cut(key, "/")
+		if !found || rest == "" {
+			continue
+		}
+		index, err := strconv.Atoi(indexPart)
+		if err != nil || index < 0 {
+			continue
+		}
+		if groups[index] == nil {
+			groups[index] = make(map[string]string)
+		}
+		groups[index][rest] = value
+	}
+
+	indices := make([]int, 0, len(groups))
+	for index := range groups {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+
+	result := make([]map[string]string, 0, len(indices))
+	for _, index := range indices {
+		result = append(result, groups[index])
+	}
+	return indices, result
+}
+
+// setStructSlice builds a slice of structs by mapping each group of values into an element.
+// indices holds the original index of each group, and key the SSM key of the slice.
+func (m *mapper) setStructSlice(fv reflect.Value, indices []int, elements []map[string]string,
+	key, fieldPath string) error {
+	// Elements are regrouped by index, so parameter types aren't tracked inside them
+	parameterTypes, valueSources, keyPrefix := m.parameterTypes, m.valueSources, m.keyPrefix
+	m.parameterTypes = nil
+	defer func() { m.parameterTypes, m.valueSources, m.keyPrefix = parameterTypes, valueSources, keyPrefix }()
+
+	elemType := fv.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	slice := reflect.MakeSlice(fv.Type(), len(elements), len(elements))
+	for i, elementValues := range elements {
+		elemPtr := reflect.New(elemType)
+		elemPath := fmt.Sprintf("%s[%d]", fieldPath, i)
+		elemKey := fmt.Sprintf("%s/%d", key, indices[i])
+		m.valueSources = filterValuesByPrefix(valueSources, elemKey)
+		m.keyPrefix = joinKey(keyPrefix, elemKey)
+		if err := m.mapStruct(elementValues, elemPtr.Interface(), elemPath); err != nil {
+			return fmt.Errorf("mapping element %d: %w", i, err)
+		}
+		if isPtr {
+			slice.Index(i).Set(elemPtr)
+		} else {
+			slice.Index(i).Set(elemPtr.Elem())
+		}
+	}
+
+	fv.Set(slice)
+	return nil
+}
+
+// isStringKeyMap reports whether the type is a map with string keys, which can be reconstructed
+// from sub-parameters (see setSubKeyMap).
+func isStringKeyMap(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String
+}
+
+// subKeyValues returns the values stored under sub-parameters of key ("labels/team" is "team"
+// for key "labels"), or nil if a value is stored under key itself, which takes precedence.
+func subKeyValues(values map[string]string, key string) map[string]string {
+	if values[key] != "" {
+		return nil
+	}
+	sub := filterValuesByPrefix(values, key)
+	delete(sub, "")
+	return sub
+}
+
+// subKeySource returns the source of a map read from sub-parameters: a file or Vault if any
+// sub-parameter came from one, SSM otherwise.
+func (m *mapper) subKeySource(key string, sub map[string]string) ValueSource {
+	for subKey := range sub {
+		if source := m.valueSource(key+"/"+subKey, true, true); source != SourceSSM {
+			return source
+		}
+	}
+	return SourceSSM
+}
+
+// setSubKeyMap sets a map field from sub-parameters, one entry per sub-key (which may itself
+// contain slashes). Values are converted to the element type like scalar fields; interface{}
+// elements hold the string value.
+func setSubKeyMap(fv reflect.Value, sub map[string]string, fieldName string) error {
+	typ := fv.Type()
+	result := reflect.MakeMapWithSize(typ, len(sub))
+	for key, val := range sub {
+		elem := reflect.New(typ.Elem()).Elem()
+		if elem.Kind() == reflect.Interface {
+			elem.Set(reflect.ValueOf(val))
+		} else if err := setFieldValue(elem, val); err != nil {
+			return fmt.Errorf("setting key '%s' of field %s: %w", key, fieldName, err)
+		}
+		result.SetMapIndex(reflect.ValueOf(key).Convert(typ.Key()), elem)
+	}
+	fv.Set(result)
+	return nil
+}
+
 //nolint:gocyclo,funlen // Complex function due to multiple type conversions and bounds checking
 func setFieldValue(fv reflect.Value, val string) error {
+	return setFieldValueWithBase(fv, val, 10)
+}
+
+// setFieldValueWithBase is setFieldValue parsing integer fields in the given base,
+// where base 0 infers it from the prefix as in Go literals ("0xFF", "0o755", "0755", "0b101").
+func setFieldValueWithBase(fv reflect.Value, val string, base int) error {
 	if !fv.CanSet() {
 		return fmt.Errorf("field cannot be set")
 	}
 
+	// Registered decoders take precedence over built-in conversions
+	if handled, err := decodeWithRegistry(fv, val); handled {
+		return err
+	}
+
 	kind := fv.Kind()
 
 	//nolint:exhaustive // We handle all supported types explicitly, default case handles unsupported types
@@ -360,7 +1099,7 @@ func setFieldValue(fv reflect.Value, val string) error {
 		fv.SetString(val)
 
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		intVal, err := strconv.ParseInt(val, 10, 64)
+		intVal, err := strconv.ParseInt(val, base, 64)
 		if err != nil {
 			return fmt.Errorf("invalid int value: %w", err)
 		}
@@ -385,10 +1124,14 @@ func setFieldValue(fv reflect.Value, val string) error {
 		fv.SetInt(intVal)
 
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		uintVal, err := strconv.ParseUint(val, 10, 64)
+		uintVal, err := strconv.ParseUint(val, base, 64)
 		if err != nil {
 			return fmt.Errorf("invalid uint value: %w", err)
 		}
+		// Check bounds for uint8, uint16, uint32 (and uint on 32-bit platforms)
+		if fv.OverflowUint(uintVal) {
+			return fmt.Errorf("value %d out of range for %s", uintVal, kind)
+		}
 		fv.SetUint(uintVal)
 
 	case reflect.Float32, reflect.Float64:
@@ -396,8 +1139,21 @@ func setFieldValue(fv reflect.Value, val string) error {
 		if err != nil {
 			return fmt.Errorf("invalid float value: %w", err)
 		}
+		if fv.OverflowFloat(floatVal) {
+			return fmt.Errorf("value %s out of range for %s", val, kind)
+		}
 		fv.SetFloat(floatVal)
 
+	case reflect.Complex64, reflect.Complex128:
+		complexVal, err := strconv.ParseComplex(val, 128)
+		if err != nil {
+			return fmt.Errorf("invalid complex value: %w", err)
+		}
+		if fv.OverflowComplex(complexVal) {
+			return fmt.Errorf("value %s out of range for %s", val, kind)
+		}
+		fv.SetComplex(complexVal)
+
 	case reflect.Bool:
 		boolVal, err := strconv.ParseBool(val)
 		if err != nil {
@@ -414,10 +1170,18 @@ func setFieldValue(fv reflect.Value, val string) error {
 				slice.Index(i).SetString(strings.TrimSpace(part))
 			}
 			fv.Set(slice)
+		} else if isPointerSlice(fv.Type()) {
+			return setPointerSlice(fv, val, base)
 		} else {
 			return fmt.Errorf("unsupported slice type: %v", fv.Type().Elem().Kind())
 		}
 
+	case reflect.Map:
+		if !isStringSet(fv.Type()) {
+			return fmt.Errorf("unsupported map type: %v", fv.Type())
+		}
+		setStringSet(fv, val)
+
 	default:
 		return fmt.Errorf("unsupported field type: %v", kind)
 	}
@@ -425,9 +1189,76 @@ func setFieldValue(fv reflect.Value, val string) error {
 	return nil
 }
 
+// isPointerSlice reports whether the type is a slice of pointers to a scalar (e.g. []*int),
+// which setPointerSlice fills from a comma-separated value.
+func isPointerSlice(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Slice || typ.Elem().Kind() != reflect.Ptr {
+		return false
+	}
+	//nolint:exhaustive // Only scalar kinds are supported
+	switch typ.Elem().Elem().Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+// setPointerSlice sets a slice of pointers from a comma-separated value, converting each
+// trimmed item like a scalar field. Empty items are nil, so "1,,3" is [*1, nil, *3] and an
+// absent element can be told apart from a zero.
+func setPointerSlice(fv reflect.Value, val string, base int) error {
+	parts := strings.Split(val, ",")
+	slice := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+	for i, part := range parts {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		elem := reflect.New(fv.Type().Elem().Elem())
+		if err := setFieldValueWithBase(elem.Elem(), part, base); err != nil {
+			return fmt.Errorf("setting element %d: %w", i, err)
+		}
+		slice.Index(i).Set(elem)
+	}
+	fv.Set(slice)
+	return nil
+}
+
+// isStringSet reports whether the type is a set of strings: map[string]bool or map[string]struct{}.
+func isStringSet(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Map || typ.Key().Kind() != reflect.String {
+		return false
+	}
+	elem := typ.Elem()
+	return elem.Kind() == reflect.Bool || (elem.Kind() == reflect.Struct && elem.NumField() == 0)
+}
+
+// setStringSet sets a set field from a comma-separated value ("a,b,c" -> {a, b, c}).
+// Items are trimmed and empty items are skipped; with map[string]bool every member maps to true.
+func setStringSet(fv reflect.Value, val string) {
+	set := reflect.MakeMap(fv.Type())
+	member := reflect.New(fv.Type().Elem()).Elem()
+	if member.Kind() == reflect.Bool {
+		member.SetBool(true)
+	}
+	for _, item := range strings.Split(val, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			set.SetMapIndex(reflect.ValueOf(item).Convert(fv.Type().Key()), member)
+		}
+	}
+	fv.Set(set)
+}
+
 // setFieldValueJSON decodes a JSON string and sets it to the field value.
 // Supports structs, slices, maps, and other JSON-serializable types.
 func setFieldValueJSON(fv reflect.Value, val string) error {
+	return setFieldValueJSONWithOptions(fv, val, &mapOptions{})
+}
+
+// setFieldValueJSONWithOptions is setFieldValueJSON honoring the JSON decoding options (e.g. strictJSON).
+func setFieldValueJSONWithOptions(fv reflect.Value, val string, opts *mapOptions) error {
 	if !fv.CanSet() {
 		return fmt.Errorf("field cannot be set")
 	}
@@ -452,14 +1283,24 @@ func setFieldValueJSON(fv reflect.Value, val string) error {
 			fv.Set(reflect.New(typ.Elem()))
 		}
 
+		if opts.lenientJSON && typ.Elem().Kind() == reflect.String {
+			setLenientJSONString(fv.Elem(), val)
+			return nil
+		}
+
 		// Decode into the pointed-to value
-		return json.Unmarshal([]byte(val), fv.Interface())
+		return decodeJSON(val, fv.Interface(), opts)
+	}
+
+	if opts.lenientJSON && kind == reflect.String {
+		setLenientJSONString(fv, val)
+		return nil
 	}
 
 	// Handle interface{} type
 	if kind == reflect.Interface {
 		var result interface{}
-		if err := json.Unmarshal([]byte(val), &result); err != nil {
+		if err := decodeJSON(val, &result, opts); err != nil {
 			return fmt.Errorf("unmarshaling JSON: %w", err)
 		}
 		fv.Set(reflect.ValueOf(result))
@@ -468,7 +1309,7 @@ func setFieldValueJSON(fv reflect.Value, val string) error {
 
 	// For non-pointer types, create a temporary pointer to unmarshal into
 	ptr := reflect.New(typ)
-	if err := json.Unmarshal([]byte(val), ptr.Interface()); err != nil {
+	if err := decodeJSON(val, ptr.Interface(), opts); err != nil {
 		return fmt.Errorf("unmarshaling JSON: %w", err)
 	}
 
@@ -476,3 +1317,40 @@ func setFieldValueJSON(fv reflect.Value, val string) error {
 	fv.Set(ptr.Elem())
 	return nil
 }
+
+// setLenientJSONString sets a string field from a JSON string, or from the literal value if
+// it isn't one (see WithLenientJSONScalars).
+func setLenientJSONString(fv reflect.Value, val string) {
+	var str string
+	if err := json.Unmarshal([]byte(val), &str); err != nil {
+		str = val
+	}
+	fv.SetString(str)
+}
+
+// decodeJSON unmarshals val into v. With strictJSON, object keys that don't match
+// a destination field are reported as errors instead of being ignored.
+// Numbers decoded into an interface{} destination are kept as json.Number rather than
+// float64, so integers such as 8080 keep their exact value and formatting.
+func decodeJSON(val string, v interface{}, opts *mapOptions) error {
+	_, isInterface := v.(*interface{})
+	if !opts.strictJSON && !isInterface {
+		return json.Unmarshal([]byte(val), v)
+	}
+
+	dec := json.NewDecoder(strings.NewReader(val))
+	if opts.strictJSON {
+		dec.DisallowUnknownFields()
+	}
+	if isInterface {
+		dec.UseNumber()
+	}
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	// Match json.Unmarshal, which rejects data after the top-level value
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("invalid data after top-level JSON value")
+	}
+	return nil
+}
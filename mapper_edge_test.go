@@ -267,4 +267,13 @@ func TestIsRequiredField(t *testing.T) {
 		assert.False(t, isRequiredField(""))
 		assert.False(t, isRequiredField("no"))
 	})
+
+	t.Run("is case-insensitive and accepts lenient spellings", func(t *testing.T) {
+		for _, tag := range []string{"TRUE", "True", "Yes", "YES", "ON", "on", "Enabled", "t"} {
+			assert.True(t, isRequiredField(tag), tag)
+		}
+		for _, tag := range []string{"FALSE", "No", "OFF", "disabled", "0", "maybe"} {
+			assert.False(t, isRequiredField(tag), tag)
+		}
+	})
 }
@@ -1,9 +1,12 @@
 package ssmconfig
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -64,6 +67,28 @@ func TestMapToStruct_BasicTypes(t *testing.T) {
 		assert.Equal(t, 3.14, result.Ratio)
 	})
 
+	t.Run("maps complex fields", func(t *testing.T) {
+		type Config struct {
+			Impedance complex128 `ssm:"impedance"`
+			Pole      complex64  `ssm:"pole"`
+		}
+
+		values := map[string]string{"impedance": "1+2i", "pole": "(-0.5-3i)"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, complex(1, 2), result.Impedance)
+		assert.Equal(t, complex64(complex(-0.5, -3)), result.Pole)
+
+		err = mapToStruct(map[string]string{"impedance": "1+2j"}, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid complex value")
+
+		err = mapToStruct(map[string]string{"pole": "1e300+1i"}, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "out of range for complex64")
+	})
+
 	t.Run("maps string slice", func(t *testing.T) {
 		type Config struct {
 			Hosts []string `ssm:"hosts"`
@@ -290,6 +315,39 @@ func TestMapToStruct_RequiredFields(t *testing.T) {
 		})
 	})
 
+	t.Run("uses requiredmsg in the strict-mode panic", func(t *testing.T) {
+		type Config struct {
+			DatabaseURL string `ssm:"database_url" required:"true" requiredmsg:"Set the database URL in SSM at /myapp/database_url"`
+		}
+
+		var result Config
+		assert.PanicsWithValue(t,
+			"ssmconfig: Missing required fields: field 'DatabaseURL': Set the database URL in SSM at /myapp/database_url",
+			func() {
+				_ = mapToStruct(map[string]string{}, &result, true, nil, true)
+			})
+	})
+
+	t.Run("names fields by their label in missing-required messages", func(t *testing.T) {
+		type Config struct {
+			Database struct {
+				Host string `ssm:"host" required:"true" label:"Database Host"`
+			} `ssm:"database"`
+		}
+
+		var logged []string
+		logger := func(format string, args ...interface{}) {
+			logged = append(logged, fmt.Sprintf(format, args...))
+		}
+		var result Config
+		assert.PanicsWithValue(t,
+			"ssmconfig: Missing required fields: field 'Database Host' (ssm:'host', env:'')",
+			func() {
+				_ = mapToStruct(map[string]string{"database/port": "5432"}, &result, true, logger, true)
+			})
+		assert.Equal(t, []string{"WARNING: Required field missing: field 'Database Host' (ssm:'host', env:'')"}, logged)
+	})
+
 	t.Run("does not panic when required field is present", func(t *testing.T) {
 		type Config struct {
 			APIKey string `ssm:"api_key" required:"true"`
@@ -574,6 +632,68 @@ func TestMapToStruct_JSONDecoding(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "env-host", result.Database.Host)
 	})
+
+	t.Run("optional JSON fields with empty values are left unset", func(t *testing.T) {
+		type DatabaseConfig struct {
+			Host string `json:"host"`
+		}
+
+		type Config struct {
+			Tags     []int          `ssm:"tags" json:"true"`
+			Labels   map[string]int `ssm:"labels" json:"1"`
+			Database DatabaseConfig `ssm:"database" json:"yes"`
+			Port     int            `ssm:"port"`
+		}
+
+		values := map[string]string{
+			"tags":     "",
+			"labels":   "   ",
+			"database": "\n\t",
+			"port":     "",
+		}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		assert.Nil(t, result.Tags)
+		assert.Nil(t, result.Labels)
+		assert.Equal(t, DatabaseConfig{}, result.Database)
+		assert.Equal(t, 0, result.Port)
+	})
+
+	t.Run("whitespace-only JSON value for required field is reported as missing", func(t *testing.T) {
+		type DatabaseConfig struct {
+			Host string `json:"host"`
+		}
+
+		type Config struct {
+			Tags     []int          `ssm:"tags" json:"true" required:"true"`
+			Database DatabaseConfig `ssm:"database" json:"yes" required:"true"`
+		}
+
+		values := map[string]string{
+			"tags":     "  ",
+			"database": " ",
+		}
+		var result Config
+		assert.PanicsWithValue(t,
+			"ssmconfig: Missing required fields: field 'Tags' (ssm:'tags', env:''), "+
+				"field 'Database' (ssm:'database', env:'')",
+			func() {
+				_ = mapToStruct(values, &result, true, nil, true)
+			})
+	})
+
+	t.Run("empty value with JSON typing preference is not an error", func(t *testing.T) {
+		type Config struct {
+			Hosts []string `ssm:"hosts"`
+		}
+
+		values := map[string]string{"hosts": "  "}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, false)
+		require.NoError(t, err)
+		assert.Nil(t, result.Hosts)
+	})
 }
 
 func TestMapToStruct_Validators(t *testing.T) {
@@ -785,6 +905,55 @@ func TestMapToStruct_EdgeCases(t *testing.T) {
 		assert.Contains(t, err.Error(), "out of range")
 	})
 
+	t.Run("handles uint16 overflow", func(t *testing.T) {
+		type Config struct {
+			Value uint16 `ssm:"value"`
+		}
+
+		values := map[string]string{"value": "70000"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "value 70000 out of range for uint16")
+	})
+
+	t.Run("handles out of range defaults", func(t *testing.T) {
+		type Config struct {
+			Port  uint16  `ssm:"port" default:"70000"`
+			Ratio float32 `ssm:"ratio" default:"1e40"`
+		}
+
+		var result Config
+		err := mapToStruct(map[string]string{"ratio": "0.5"}, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "setting field Port: value 70000 out of range for uint16")
+
+		err = mapToStruct(map[string]string{"port": "8080"}, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "setting field Ratio: value 1e40 out of range for float32")
+	})
+
+	t.Run("handles slices of pointers", func(t *testing.T) {
+		type Config struct {
+			Limits  []*int     `ssm:"limits"`
+			Enabled []*bool    `ssm:"enabled"`
+			Names   []*string  `ssm:"names"`
+			Ratios  []*float64 `ssm:"ratios"`
+		}
+
+		values := map[string]string{"limits": "1,,3", "enabled": "true, ,false", "names": "a,", "ratios": "0.5"}
+		var result Config
+		require.NoError(t, mapToStruct(values, &result, false, nil, true))
+		assert.Equal(t, []*int{ToPointerValue(1), nil, ToPointerValue(3)}, result.Limits)
+		assert.Equal(t, []*bool{ToPointerValue(true), nil, ToPointerValue(false)}, result.Enabled)
+		assert.Equal(t, []*string{ToPointerValue("a"), nil}, result.Names)
+		assert.Equal(t, []*float64{ToPointerValue(0.5)}, result.Ratios)
+
+		err := mapToStruct(map[string]string{"limits": "1,x"}, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "setting element 1: invalid int value")
+	})
+
 	t.Run("handles unsupported slice type", func(t *testing.T) {
 		type Config struct {
 			Values []int `ssm:"values"`
@@ -849,8 +1018,8 @@ func TestMapToStruct_EdgeCases(t *testing.T) {
 		values := map[string]string{"port": "   "} // Whitespace only
 		var result Config
 		err := mapToStruct(values, &result, false, nil, true)
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "empty JSON string")
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Port)
 	})
 
 	t.Run("handles nested pointer in JSON", func(t *testing.T) {
@@ -876,6 +1045,23 @@ func TestMapToStruct_EdgeCases(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, result.Value)
 	})
+
+	t.Run("keeps numbers in interface{} fields as json.Number", func(t *testing.T) {
+		type Config struct {
+			Value interface{} `ssm:"value" json:"true"`
+		}
+
+		values := map[string]string{"value": `{"port":8080}`}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+
+		decoded, ok := result.Value.(map[string]interface{})
+		require.True(t, ok)
+		assert.NotEqual(t, reflect.Float64, reflect.TypeOf(decoded["port"]).Kind())
+		assert.Equal(t, json.Number("8080"), decoded["port"])
+		assert.Equal(t, "8080", fmt.Sprint(decoded["port"]))
+	})
 }
 
 func TestValidateRequiredFields(t *testing.T) {
@@ -897,6 +1083,16 @@ func TestValidateRequiredFields(t *testing.T) {
 		assert.Len(t, loggedMessages, 1)
 	})
 
+	t.Run("uses requiredmsg when present", func(t *testing.T) {
+		type Config struct {
+			APIKey string `ssm:"api_key" required:"true" requiredmsg:"Create /myapp/api_key in SSM"`
+		}
+
+		err := ValidateRequiredFields[Config](map[string]string{}, nil)
+		require.Error(t, err)
+		assert.EqualError(t, err, "missing required fields: field 'APIKey': Create /myapp/api_key in SSM")
+	})
+
 	t.Run("passes when all required fields present", func(t *testing.T) {
 		type Config struct {
 			APIKey string `ssm:"api_key" required:"true"`
@@ -988,6 +1184,41 @@ func TestSetFieldValue_ErrorCases(t *testing.T) {
 	})
 }
 
+func TestMapToStruct_StringSets(t *testing.T) {
+	type Role string
+	type Config struct {
+		Features map[string]bool     `ssm:"features"`
+		Regions  map[string]struct{} `ssm:"regions"`
+		Roles    map[Role]bool       `ssm:"roles"`
+	}
+
+	t.Run("maps comma-separated values to set members", func(t *testing.T) {
+		values := map[string]string{
+			"features": "search, billing,,beta ",
+			"regions":  "us-east-1,eu-west-1",
+			"roles":    "admin",
+		}
+
+		var result Config
+		require.NoError(t, mapToStruct(values, &result, false, nil, true))
+		assert.Equal(t, map[string]bool{"search": true, "billing": true, "beta": true}, result.Features)
+		assert.Equal(t, map[string]struct{}{"us-east-1": {}, "eu-west-1": {}}, result.Regions)
+		assert.Equal(t, map[Role]bool{"admin": true}, result.Roles)
+		assert.False(t, result.Features["unknown"])
+	})
+
+	t.Run("rejects other map types", func(t *testing.T) {
+		type MapConfig struct {
+			Limits map[string]int `ssm:"limits"`
+		}
+
+		var result MapConfig
+		err := mapToStruct(map[string]string{"limits": "a,b"}, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported map type: map[string]int")
+	})
+}
+
 func TestSetFieldValueJSON_ErrorCases(t *testing.T) {
 	t.Run("handles unsettable field", func(t *testing.T) {
 		type Config struct {
@@ -1026,3 +1257,550 @@ func TestSetFieldValueJSON_ErrorCases(t *testing.T) {
 		assert.Contains(t, err.Error(), "unmarshaling JSON")
 	})
 }
+
+func TestMapToStruct_StructSlices(t *testing.T) {
+	type Item struct {
+		Name string `ssm:"name"`
+		ID   int    `ssm:"id"`
+	}
+
+	t.Run("reconstructs slice of structs from indexed keys", func(t *testing.T) {
+		type Config struct {
+			Items []Item `ssm:"items"`
+		}
+
+		values := map[string]string{
+			"items/0/name": "first",
+			"items/0/id":   "1",
+			"items/1/name": "second",
+			"items/1/id":   "2",
+		}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, []Item{{Name: "first", ID: 1}, {Name: "second", ID: 2}}, result.Items)
+	})
+
+	t.Run("reconstructs slice of struct pointers", func(t *testing.T) {
+		type Config struct {
+			Items []*Item `ssm:"items"`
+		}
+
+		values := map[string]string{"items/0/name": "first"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, "first", result.Items[0].Name)
+	})
+
+	t.Run("compacts sparse indices in index order", func(t *testing.T) {
+		type Config struct {
+			Items []Item `ssm:"items"`
+		}
+
+		values := map[string]string{
+			"items/10/name": "third",
+			"items/2/name":  "second",
+			"items/0/name":  "first",
+		}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		require.Len(t, result.Items, 3)
+		assert.Equal(t, "first", result.Items[0].Name)
+		assert.Equal(t, "second", result.Items[1].Name)
+		assert.Equal(t, "third", result.Items[2].Name)
+	})
+
+	t.Run("ignores non-numeric indices", func(t *testing.T) {
+		type Config struct {
+			Items []Item `ssm:"items"`
+		}
+
+		values := map[string]string{
+			"items/0/name":     "first",
+			"items/extra/name": "ignored",
+		}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		require.Len(t, result.Items, 1)
+	})
+
+	t.Run("reports element conversion errors", func(t *testing.T) {
+		type Config struct {
+			Items []Item `ssm:"items"`
+		}
+
+		values := map[string]string{"items/0/id": "not-a-number"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mapping element 0")
+	})
+
+	t.Run("uses JSON value for json-tagged field", func(t *testing.T) {
+		type Config struct {
+			Items []Item `ssm:"items" json:"true"`
+		}
+
+		values := map[string]string{
+			"items":        `[{"Name":"from-json"}]`,
+			"items/0/name": "from-keys",
+		}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		require.Len(t, result.Items, 1)
+		assert.Equal(t, "from-json", result.Items[0].Name)
+	})
+}
+
+func TestMapToStruct_FieldErrors(t *testing.T) {
+	type Database struct {
+		Port int `ssm:"port"`
+	}
+	type Config struct {
+		Port     int      `ssm:"port"`
+		Debug    bool     `ssm:"debug"`
+		Name     string   `ssm:"name"`
+		Database Database `ssm:"database"`
+	}
+
+	values := map[string]string{
+		"port":          "not-a-number",
+		"debug":         "maybe",
+		"name":          "app",
+		"database/port": "invalid",
+	}
+
+	t.Run("stops at first error by default", func(t *testing.T) {
+		var failedFields []string
+		opts := &mapOptions{
+			useStrongTyping: true,
+			onFieldError: func(field string, err error) {
+				failedFields = append(failedFields, field)
+			},
+		}
+
+		var result Config
+		err := mapToStructWithOptions(values, &result, opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "setting field Port")
+		assert.Equal(t, []string{"Port"}, failedFields)
+	})
+
+	t.Run("collects all errors and reports each field", func(t *testing.T) {
+		var failedFields []string
+		opts := &mapOptions{
+			useStrongTyping: true,
+			collectErrors:   true,
+			onFieldError: func(field string, err error) {
+				failedFields = append(failedFields, field)
+			},
+		}
+
+		var result Config
+		err := mapToStructWithOptions(values, &result, opts)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "setting field Port")
+		assert.Contains(t, err.Error(), "setting field Debug")
+		assert.Equal(t, []string{"Port", "Debug", "Database.Port"}, failedFields)
+		assert.Equal(t, "app", result.Name)
+	})
+
+	t.Run("collected errors can be inspected with errors.Is", func(t *testing.T) {
+		opts := &mapOptions{useStrongTyping: true, collectErrors: true}
+
+		var result Config
+		err := mapToStructWithOptions(values, &result, opts)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, strconv.ErrSyntax)
+		var numErr *strconv.NumError
+		assert.True(t, errors.As(err, &numErr))
+	})
+}
+
+func TestMapToStruct_LenientBool(t *testing.T) {
+	type Config struct {
+		Enabled bool `ssm:"enabled"`
+	}
+
+	tests := []struct {
+		value    string
+		expected bool
+	}{
+		{"yes", true},
+		{"YES", true},
+		{"on", true},
+		{"On", true},
+		{"enabled", true},
+		{"no", false},
+		{"off", false},
+		{"disabled", false},
+		{"Disabled", false},
+		{"true", true},
+		{"0", false},
+	}
+
+	for _, tt := range tests {
+		t.Run("accepts "+tt.value, func(t *testing.T) {
+			values := map[string]string{"enabled": tt.value}
+			result := Config{Enabled: !tt.expected}
+			err := mapToStructWithOptions(values, &result, &mapOptions{useStrongTyping: true, lenientBool: true})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result.Enabled)
+		})
+	}
+
+	t.Run("rejects unknown spelling", func(t *testing.T) {
+		values := map[string]string{"enabled": "maybe"}
+		var result Config
+		err := mapToStructWithOptions(values, &result, &mapOptions{useStrongTyping: true, lenientBool: true})
+		require.Error(t, err)
+	})
+
+	t.Run("keeps strict parsing by default", func(t *testing.T) {
+		values := map[string]string{"enabled": "yes"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid bool value")
+	})
+}
+
+func TestMapToStruct_Squash(t *testing.T) {
+	type Common struct {
+		Region  string `ssm:"region"`
+		Timeout int    `ssm:"timeout" required:"true"`
+	}
+
+	t.Run("maps squashed struct fields at parent level", func(t *testing.T) {
+		type Config struct {
+			Common `ssm:",squash"`
+			Name   string `ssm:"name"`
+		}
+
+		values := map[string]string{"region": "eu-west-1", "timeout": "30", "name": "app"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, "eu-west-1", result.Region)
+		assert.Equal(t, 30, result.Timeout)
+		assert.Equal(t, "app", result.Name)
+	})
+
+	t.Run("supports squash tag on named pointer field", func(t *testing.T) {
+		type Config struct {
+			Shared *Common `squash:"true"`
+		}
+
+		values := map[string]string{"region": "us-east-1", "timeout": "10"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		require.NotNil(t, result.Shared)
+		assert.Equal(t, "us-east-1", result.Shared.Region)
+		assert.Equal(t, 10, result.Shared.Timeout)
+	})
+
+	t.Run("does not squash without tag", func(t *testing.T) {
+		type Config struct {
+			Common Common
+		}
+
+		values := map[string]string{"region": "eu-west-1", "common/region": "us-west-2", "common/timeout": "5"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, "us-west-2", result.Common.Region)
+	})
+
+	t.Run("squashed required fields are checked", func(t *testing.T) {
+		type Config struct {
+			Common `ssm:",squash"`
+		}
+
+		values := map[string]string{"region": "eu-west-1"}
+		var result Config
+		assert.Panics(t, func() {
+			_ = mapToStruct(values, &result, true, nil, true)
+		})
+	})
+
+	t.Run("lists squashed parameters at parent level", func(t *testing.T) {
+		type Config struct {
+			Common `ssm:",squash"`
+		}
+
+		assert.Equal(t, []string{"/myapp/timeout"}, ListRequiredParameters[Config]("/myapp/"))
+	})
+}
+
+func TestMapToStruct_FallbackToJSONName(t *testing.T) {
+	type Database struct {
+		Host string `json:"host"`
+	}
+
+	type Config struct {
+		DBHost   string   `json:"dbHost,omitempty"`
+		Port     int      `json:"port"`
+		Explicit string   `ssm:"explicit_key" json:"explicit"`
+		Skipped  string   `json:"-"`
+		Database Database `json:"db"`
+	}
+
+	values := map[string]string{
+		"dbHost":       "db.example.com",
+		"port":         "5432",
+		"explicit_key": "from-ssm-tag",
+		"explicit":     "from-json-name",
+		"Skipped":      "x",
+		"-":            "x",
+		"db/host":      "nested-host",
+	}
+
+	t.Run("uses json tag name when enabled", func(t *testing.T) {
+		var result Config
+		err := mapToStructWithOptions(values, &result, &mapOptions{useStrongTyping: true, jsonNameKeys: true})
+		require.NoError(t, err)
+		assert.Equal(t, "db.example.com", result.DBHost)
+		assert.Equal(t, 5432, result.Port)
+		assert.Equal(t, "from-ssm-tag", result.Explicit)
+		assert.Empty(t, result.Skipped)
+		assert.Equal(t, "nested-host", result.Database.Host)
+	})
+
+	t.Run("ignores json tag names by default", func(t *testing.T) {
+		var result Config
+		err := mapToStructWithOptions(values, &result, &mapOptions{useStrongTyping: true})
+		require.NoError(t, err)
+		assert.Empty(t, result.DBHost)
+		assert.Zero(t, result.Port)
+	})
+
+	t.Run("json decoding marker is not a key", func(t *testing.T) {
+		type JSONConfig struct {
+			Tags []string `json:"true"`
+		}
+
+		var result JSONConfig
+		err := mapToStructWithOptions(map[string]string{"true": `["a"]`}, &result,
+			&mapOptions{useStrongTyping: true, jsonNameKeys: true})
+		require.NoError(t, err)
+		assert.Nil(t, result.Tags)
+	})
+}
+
+func TestMapToStruct_StrictJSON(t *testing.T) {
+	type Database struct {
+		Host string `json:"host"`
+		Port int    `json:"port"`
+	}
+
+	type Config struct {
+		Database Database            `ssm:"database" json:"true"`
+		Replicas []Database          `ssm:"replicas" json:"1"`
+		Extra    map[string]Database `ssm:"extra" json:"yes"`
+	}
+
+	t.Run("ignores unknown JSON keys by default", func(t *testing.T) {
+		values := map[string]string{"database": `{"host":"db","prot":5432}`}
+		var result Config
+		err := mapToStructWithOptions(values, &result, &mapOptions{useStrongTyping: true})
+		require.NoError(t, err)
+		assert.Equal(t, "db", result.Database.Host)
+		assert.Zero(t, result.Database.Port)
+	})
+
+	t.Run("rejects unknown keys in nested struct JSON", func(t *testing.T) {
+		values := map[string]string{"database": `{"host":"db","prot":5432}`}
+		var result Config
+		err := mapToStructWithOptions(values, &result, &mapOptions{useStrongTyping: true, strictJSON: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown field "prot"`)
+	})
+
+	t.Run("rejects unknown keys in slice and map elements", func(t *testing.T) {
+		var result Config
+		err := mapToStructWithOptions(map[string]string{"replicas": `[{"hots":"r1"}]`}, &result,
+			&mapOptions{useStrongTyping: true, strictJSON: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown field "hots"`)
+
+		err = mapToStructWithOptions(map[string]string{"extra": `{"a":{"name":"x"}}`}, &result,
+			&mapOptions{useStrongTyping: true, strictJSON: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown field "name"`)
+	})
+
+	t.Run("accepts matching JSON", func(t *testing.T) {
+		values := map[string]string{
+			"database": `{"host":"db","port":5432}`,
+			"replicas": `[{"host":"r1"}]`,
+		}
+		var result Config
+		err := mapToStructWithOptions(values, &result, &mapOptions{useStrongTyping: true, strictJSON: true})
+		require.NoError(t, err)
+		assert.Equal(t, 5432, result.Database.Port)
+		assert.Equal(t, "r1", result.Replicas[0].Host)
+	})
+
+	t.Run("rejects trailing data like json.Unmarshal", func(t *testing.T) {
+		values := map[string]string{"replicas": `[{"host":"r1"}] []`}
+		var result Config
+		err := mapToStructWithOptions(values, &result, &mapOptions{useStrongTyping: true, strictJSON: true})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid data after top-level JSON value")
+	})
+}
+
+func TestMapToStruct_Gate(t *testing.T) {
+	type Redis struct {
+		Addr string `ssm:"addr" required:"true"`
+	}
+
+	type Config struct {
+		Redis  Redis  `ssm:"redis" gate:"features/redis_enabled"`
+		Cache  *Redis `ssm:"cache" gate:"features/cache_enabled"`
+		Region string `ssm:"region"`
+	}
+
+	t.Run("loads the struct when the gate is true", func(t *testing.T) {
+		values := map[string]string{
+			"features/redis_enabled": "true",
+			"redis/addr":             "redis:6379",
+			"region":                 "eu-west-1",
+		}
+
+		var result Config
+		err := mapToStruct(values, &result, true, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, "redis:6379", result.Redis.Addr)
+		assert.Equal(t, "eu-west-1", result.Region)
+	})
+
+	t.Run("skips the struct and its required checks when the gate is false or missing", func(t *testing.T) {
+		values := map[string]string{
+			"features/redis_enabled": "false",
+			"redis/addr":             "redis:6379",
+			"cache/addr":             "cache:6379",
+			"region":                 "eu-west-1",
+		}
+
+		var result Config
+		assert.NotPanics(t, func() {
+			err := mapToStruct(values, &result, true, nil, true)
+			require.NoError(t, err)
+		})
+		assert.Empty(t, result.Redis.Addr)
+		assert.Nil(t, result.Cache)
+		assert.Equal(t, "eu-west-1", result.Region)
+	})
+
+	t.Run("accepts lenient spellings with lenientBool", func(t *testing.T) {
+		values := map[string]string{
+			"features/redis_enabled": "on",
+			"redis/addr":             "redis:6379",
+		}
+
+		var result Config
+		err := mapToStructWithOptions(values, &result, &mapOptions{useStrongTyping: true, lenientBool: true})
+		require.NoError(t, err)
+		assert.Equal(t, "redis:6379", result.Redis.Addr)
+	})
+}
+
+func TestMapToStruct_IntBase(t *testing.T) {
+	type Config struct {
+		Mode    os.FileMode `ssm:"mode" base:"8"`
+		Mask    uint32      `ssm:"mask" base:"16"`
+		Auto    int         `ssm:"auto" base:"0"`
+		AutoOct int         `ssm:"auto_oct" base:"0"`
+		Decimal int         `ssm:"decimal"`
+	}
+
+	t.Run("parses integers in the tagged base", func(t *testing.T) {
+		values := map[string]string{
+			"mode":     "0755",
+			"mask":     "FF",
+			"auto":     "0xFF",
+			"auto_oct": "0755",
+			"decimal":  "0755",
+		}
+
+		var result Config
+		require.NoError(t, mapToStruct(values, &result, false, nil, true))
+		assert.Equal(t, os.FileMode(0o755), result.Mode)
+		assert.Equal(t, uint32(0xFF), result.Mask)
+		assert.Equal(t, 255, result.Auto)
+		assert.Equal(t, 0o755, result.AutoOct)
+		assert.Equal(t, 755, result.Decimal)
+	})
+
+	t.Run("rejects digits outside the base", func(t *testing.T) {
+		var result Config
+		err := mapToStruct(map[string]string{"mode": "0789"}, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "setting field Mode: invalid uint value")
+	})
+
+	t.Run("rejects invalid bases", func(t *testing.T) {
+		type BadConfig struct {
+			Port int `ssm:"port" base:"1"`
+		}
+
+		var result BadConfig
+		err := mapToStruct(map[string]string{"port": "80"}, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid base '1' for field Port (want 0 or 2-36)")
+	})
+}
+
+func TestMapToStruct_MapFromSubParameters(t *testing.T) {
+	type Config struct {
+		Labels map[string]string      `ssm:"labels" json:"true"`
+		Limits map[string]int         `ssm:"limits"`
+		Extra  map[string]interface{} `ssm:"extra"`
+	}
+
+	t.Run("reads maps from either layout", func(t *testing.T) {
+		values := map[string]string{
+			"labels":           `{"team":"core","tier":"web"}`,
+			"limits/cpu":       "2",
+			"limits/memory":    "512",
+			"extra/region":     "eu-west-1",
+			"extra/zones/main": "a",
+		}
+
+		var result Config
+		require.NoError(t, mapToStruct(values, &result, true, nil, true))
+		assert.Equal(t, map[string]string{"team": "core", "tier": "web"}, result.Labels)
+		assert.Equal(t, map[string]int{"cpu": 2, "memory": 512}, result.Limits)
+		assert.Equal(t, map[string]interface{}{"region": "eu-west-1", "zones/main": "a"}, result.Extra)
+	})
+
+	t.Run("sub-parameters of a JSON field", func(t *testing.T) {
+		values := map[string]string{"labels/team": "core", "labels/tier": "web"}
+
+		var result Config
+		require.NoError(t, mapToStruct(values, &result, false, nil, true))
+		assert.Equal(t, map[string]string{"team": "core", "tier": "web"}, result.Labels)
+	})
+
+	t.Run("a value under the key itself wins", func(t *testing.T) {
+		values := map[string]string{"labels": `{"team":"core"}`, "labels/tier": "web"}
+
+		var result Config
+		require.NoError(t, mapToStruct(values, &result, false, nil, true))
+		assert.Equal(t, map[string]string{"team": "core"}, result.Labels)
+	})
+
+	t.Run("rejects values of the wrong type", func(t *testing.T) {
+		var result Config
+		err := mapToStruct(map[string]string{"limits/cpu": "two"}, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "setting key 'cpu' of field Limits")
+	})
+}
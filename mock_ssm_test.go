@@ -0,0 +1,141 @@
+package ssmconfig
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// mockSSMClient is an in-memory ssmAPI implementation for tests.
+type mockSSMClient struct {
+	mu             sync.Mutex
+	parameters     map[string]string              // full parameter name -> value
+	parameterTypes map[string]types.ParameterType // full parameter name -> type (default String)
+	modified       map[string]time.Time           // full parameter name -> LastModifiedDate (default unset)
+	err            error
+	calls          int
+	delay          time.Duration // If set, each GetParametersByPath call waits this long (or until ctx is done)
+	maxResults     []*int32      // MaxResults of each GetParametersByPath call
+	failAfter      int           // If set, GetParametersByPath calls after this many fail with err
+}
+
+func newMockSSMClient(parameters map[string]string) *mockSSMClient {
+	return &mockSSMClient{parameters: parameters}
+}
+
+func (m *mockSSMClient) GetParameter(_ context.Context, params *ssm.GetParameterInput,
+	_ ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	value, ok := m.parameters[*params.Name]
+	if !ok {
+		return nil, &types.ParameterNotFound{Message: ToPointerValue("parameter not found")}
+	}
+
+	return &ssm.GetParameterOutput{
+		Parameter: &types.Parameter{Name: params.Name, Value: ToPointerValue(value)},
+	}, nil
+}
+
+func (m *mockSSMClient) GetParametersByPath(ctx context.Context, params *ssm.GetParametersByPathInput,
+	_ ...func(*ssm.Options)) (*ssm.GetParametersByPathOutput, error) {
+	if m.delay > 0 {
+		select {
+		case <-time.After(m.delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+	m.maxResults = append(m.maxResults, params.MaxResults)
+	if m.err != nil && (m.failAfter == 0 || m.calls > m.failAfter) {
+		return nil, m.err
+	}
+
+	names := make([]string, 0, len(m.parameters))
+	for name := range m.parameters {
+		if strings.HasPrefix(name, *params.Path) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	// Paginate when MaxResults is set; the token is the index of the next parameter
+	start := 0
+	if params.NextToken != nil {
+		var err error
+		if start, err = strconv.Atoi(*params.NextToken); err != nil {
+			return nil, err
+		}
+	}
+	names = names[start:]
+
+	out := &ssm.GetParametersByPathOutput{}
+	if params.MaxResults != nil && int(*params.MaxResults) < len(names) {
+		names = names[:*params.MaxResults]
+		out.NextToken = ToPointerValue(strconv.Itoa(start + len(names)))
+	}
+
+	for _, name := range names {
+		typ := m.parameterTypes[name]
+		if typ == "" {
+			typ = types.ParameterTypeString
+		}
+		parameter := types.Parameter{
+			Name:  ToPointerValue(name),
+			Value: ToPointerValue(m.parameters[name]),
+			Type:  typ,
+		}
+		if modified, ok := m.modified[name]; ok {
+			parameter.LastModifiedDate = ToPointerValue(modified)
+		}
+		out.Parameters = append(out.Parameters, parameter)
+	}
+
+	return out, nil
+}
+
+func (m *mockSSMClient) setParameter(name, value string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.parameters[name] = value
+}
+
+// setParameterAt sets a parameter along with its LastModifiedDate.
+func (m *mockSSMClient) setParameterAt(name, value string, modified time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.parameters[name] = value
+	if m.modified == nil {
+		m.modified = make(map[string]time.Time)
+	}
+	m.modified[name] = modified
+}
+
+func (m *mockSSMClient) deleteParameter(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.parameters, name)
+}
+
+func (m *mockSSMClient) callCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls
+}
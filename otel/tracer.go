@@ -0,0 +1,59 @@
+// Package ssmconfigotel adapts an OpenTelemetry tracer to ssmconfig.Tracer, so the loader's
+// spans are exported like the rest of a service's traces:
+//
+//	loader, err := ssmconfig.NewLoader(ctx,
+//	    ssmconfig.WithTracer(ssmconfigotel.Tracer(otel.Tracer("ssmconfig"))))
+//
+// It is a separate module, so ssmconfig itself doesn't depend on OpenTelemetry.
+package ssmconfigotel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ali63yavari/ssmconfig"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer returns an ssmconfig.Tracer that starts its spans with tracer.
+func Tracer(tracer trace.Tracer) ssmconfig.Tracer {
+	return otelTracer{tracer: tracer}
+}
+
+type otelTracer struct {
+	tracer trace.Tracer
+}
+
+func (t otelTracer) Start(ctx context.Context, spanName string) (context.Context, ssmconfig.Span) {
+	ctx, span := t.tracer.Start(ctx, spanName)
+	return ctx, otelSpan{span: span}
+}
+
+// otelSpan records the loader's attributes and errors on an OpenTelemetry span.
+type otelSpan struct {
+	span trace.Span
+}
+
+// SetAttribute records ints as int attributes and any other value as a string.
+func (s otelSpan) SetAttribute(key string, value any) {
+	switch v := value.(type) {
+	case int:
+		s.span.SetAttributes(attribute.Int(key, v))
+	case string:
+		s.span.SetAttributes(attribute.String(key, v))
+	default:
+		s.span.SetAttributes(attribute.String(key, fmt.Sprint(v)))
+	}
+}
+
+// RecordError records err as an exception event and marks the span as failed.
+func (s otelSpan) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, err.Error())
+}
+
+func (s otelSpan) End() {
+	s.span.End()
+}
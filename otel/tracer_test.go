@@ -0,0 +1,75 @@
+package ssmconfigotel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// recordingSpan keeps what the adapter sets on it.
+type recordingSpan struct {
+	noop.Span
+	name       string
+	attributes []attribute.KeyValue
+	errors     []error
+	status     codes.Code
+	ended      bool
+}
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	s.attributes = append(s.attributes, kv...)
+}
+
+func (s *recordingSpan) RecordError(err error, _ ...trace.EventOption) {
+	s.errors = append(s.errors, err)
+}
+
+func (s *recordingSpan) SetStatus(code codes.Code, _ string) {
+	s.status = code
+}
+
+func (s *recordingSpan) End(...trace.SpanEndOption) {
+	s.ended = true
+}
+
+// recordingTracer is a trace.Tracer that keeps every span it starts.
+type recordingTracer struct {
+	noop.Tracer
+	spans []*recordingSpan
+}
+
+func (t *recordingTracer) Start(ctx context.Context, spanName string,
+	_ ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &recordingSpan{name: spanName}
+	t.spans = append(t.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+func TestTracer(t *testing.T) {
+	tracer := &recordingTracer{}
+
+	ctx, span := Tracer(tracer).Start(context.Background(), "ssmconfig.loadFromSSM")
+	span.SetAttribute("ssmconfig.prefix", "/myapp")
+	span.SetAttribute("ssmconfig.pages", 2)
+	span.RecordError(errors.New("throttled"))
+	span.End()
+
+	if assert.Len(t, tracer.spans, 1) {
+		recorded := tracer.spans[0]
+		assert.Equal(t, "ssmconfig.loadFromSSM", recorded.name)
+		assert.Same(t, recorded, trace.SpanFromContext(ctx))
+		assert.Equal(t, []attribute.KeyValue{
+			attribute.String("ssmconfig.prefix", "/myapp"),
+			attribute.Int("ssmconfig.pages", 2),
+		}, recorded.attributes)
+		assert.Equal(t, []error{errors.New("throttled")}, recorded.errors)
+		assert.Equal(t, codes.Error, recorded.status)
+		assert.True(t, recorded.ended)
+	}
+}
@@ -0,0 +1,50 @@
+package ssmconfig
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// pathValidators are the built-in validators that stat the field value as a path, mapped to the
+// kind of entry they require ("" for any). Unlike registered validators they need the loader's
+// filesystem, so validateField resolves them itself after the registry.
+var pathValidators = map[string]string{
+	"pathexists": "",
+	"fileexists": "file",
+	"direxists":  "directory",
+}
+
+// validatePath checks that the path held by value exists in fsys, or on the local disk if fsys
+// is nil, and is of the kind the named path validator requires. As with fromfile fields, a
+// leading slash is dropped for fsys, whose paths can't be rooted.
+func validatePath(fsys fs.FS, name string, value interface{}) error {
+	path, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("%s validator requires string type", name)
+	}
+
+	var info fs.FileInfo
+	var err error
+	if fsys != nil {
+		info, err = fs.Stat(fsys, strings.TrimPrefix(path, "/"))
+	} else {
+		info, err = os.Stat(path)
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("path %s does not exist", path)
+	}
+	if err != nil {
+		return fmt.Errorf("checking path %s: %w", path, err)
+	}
+
+	switch kind := pathValidators[name]; {
+	case kind == "file" && info.IsDir():
+		return fmt.Errorf("path %s is a directory, not a file", path)
+	case kind == "directory" && !info.IsDir():
+		return fmt.Errorf("path %s is not a directory", path)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package ssmconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathValidators(t *testing.T) {
+	type Config struct {
+		Any  string `ssm:"any" validate:"pathexists"`
+		File string `ssm:"file" validate:"fileexists"`
+		Dir  string `ssm:"dir" validate:"direxists"`
+	}
+
+	fsys := fstest.MapFS{
+		"etc/app/config.yaml": {Data: []byte("host: localhost\n")},
+		"var/data/.keep":      {},
+	}
+	mapWithFS := func(values map[string]string) error {
+		var cfg Config
+		return mapToStructWithOptions(values, &cfg, &mapOptions{useStrongTyping: true, fsys: fsys})
+	}
+
+	t.Run("accepts existing paths in the configured fs", func(t *testing.T) {
+		require.NoError(t, mapWithFS(map[string]string{
+			"any":  "/var/data",
+			"file": "/etc/app/config.yaml",
+			"dir":  "var/data",
+		}))
+	})
+
+	t.Run("rejects missing paths and the wrong kind", func(t *testing.T) {
+		tests := []struct {
+			key, value, want string
+		}{
+			{"any", "/mnt/missing", "using validator 'pathexists': path /mnt/missing does not exist"},
+			{"file", "/var/data", "using validator 'fileexists': path /var/data is a directory, not a file"},
+			{"dir", "/etc/app/config.yaml", "using validator 'direxists': path /etc/app/config.yaml is not a directory"},
+		}
+		for _, tt := range tests {
+			err := mapWithFS(map[string]string{tt.key: tt.value})
+			require.Error(t, err, tt.key)
+			assert.Contains(t, err.Error(), tt.want)
+		}
+	})
+
+	t.Run("uses the local disk without an fs", func(t *testing.T) {
+		dir := t.TempDir()
+		file := filepath.Join(dir, "ca.pem")
+		require.NoError(t, os.WriteFile(file, []byte("cert"), 0o600))
+
+		var cfg Config
+		require.NoError(t, mapToStruct(map[string]string{"any": file, "file": file, "dir": dir}, &cfg, false, nil, true))
+
+		err := mapToStruct(map[string]string{"dir": filepath.Join(dir, "missing")}, &cfg, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not exist")
+	})
+
+	t.Run("requires a string", func(t *testing.T) {
+		type IntConfig struct {
+			Port int `ssm:"port" validate:"pathexists"`
+		}
+
+		var cfg IntConfig
+		err := mapToStruct(map[string]string{"port": "8080"}, &cfg, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "pathexists validator requires string type")
+	})
+}
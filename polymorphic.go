@@ -0,0 +1,101 @@
+package ssmconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// polymorphicType describes how to pick the concrete type for an interface field.
+type polymorphicType struct {
+	discriminator string
+	types         map[string]reflect.Type
+}
+
+var (
+	polymorphicTypes   = make(map[string]*polymorphicType)
+	polymorphicTypesMu sync.RWMutex
+)
+
+// RegisterPolymorphic registers the concrete types for an interface field loaded from the given
+// SSM key (the field's ssm tag). The value is JSON; the discriminator property selects the type
+// to instantiate before unmarshaling, e.g.:
+//
+//	ssmconfig.RegisterPolymorphic("storage", "type", map[string]reflect.Type{
+//	    "s3":   reflect.TypeOf(S3Storage{}),
+//	    "disk": reflect.TypeOf(&DiskStorage{}),
+//	})
+//
+// A pointer type is populated through a newly allocated value; the instantiated value must
+// implement the field's interface type.
+func RegisterPolymorphic(key, discriminator string, types map[string]reflect.Type) {
+	polymorphicTypesMu.Lock()
+	defer polymorphicTypesMu.Unlock()
+	polymorphicTypes[key] = &polymorphicType{discriminator: discriminator, types: types}
+}
+
+// UnregisterPolymorphic removes the polymorphic registration for an SSM key.
+func UnregisterPolymorphic(key string) {
+	polymorphicTypesMu.Lock()
+	defer polymorphicTypesMu.Unlock()
+	delete(polymorphicTypes, key)
+}
+
+// getPolymorphic retrieves the polymorphic registration for an SSM key.
+func getPolymorphic(key string) (*polymorphicType, bool) {
+	polymorphicTypesMu.RLock()
+	defer polymorphicTypesMu.RUnlock()
+	p, ok := polymorphicTypes[key]
+	return p, ok
+}
+
+// decode instantiates the type selected by the discriminator, unmarshals val into it,
+// and sets it to the interface field.
+func (p *polymorphicType) decode(fv reflect.Value, val string, opts *mapOptions) error {
+	var properties map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(val), &properties); err != nil {
+		return fmt.Errorf("unmarshaling JSON: %w", err)
+	}
+
+	raw, ok := properties[p.discriminator]
+	if !ok {
+		return fmt.Errorf("missing discriminator property %q", p.discriminator)
+	}
+	var name string
+	if err := json.Unmarshal(raw, &name); err != nil {
+		return fmt.Errorf("discriminator property %q must be a string: %w", p.discriminator, err)
+	}
+
+	typ, ok := p.types[name]
+	if !ok {
+		known := make([]string, 0, len(p.types))
+		for k := range p.types {
+			known = append(known, k)
+		}
+		sort.Strings(known)
+		return fmt.Errorf("unknown %s %q (expected one of: %s)", p.discriminator, name, strings.Join(known, ", "))
+	}
+
+	var result reflect.Value
+	if typ.Kind() == reflect.Ptr {
+		result = reflect.New(typ.Elem())
+		if err := decodeJSON(val, result.Interface(), opts); err != nil {
+			return fmt.Errorf("unmarshaling JSON into %v: %w", typ, err)
+		}
+	} else {
+		ptr := reflect.New(typ)
+		if err := decodeJSON(val, ptr.Interface(), opts); err != nil {
+			return fmt.Errorf("unmarshaling JSON into %v: %w", typ, err)
+		}
+		result = ptr.Elem()
+	}
+
+	if !result.Type().AssignableTo(fv.Type()) {
+		return fmt.Errorf("%v does not implement %v", result.Type(), fv.Type())
+	}
+	fv.Set(result)
+	return nil
+}
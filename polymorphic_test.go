@@ -0,0 +1,94 @@
+package ssmconfig
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStorage interface {
+	Location() string
+}
+
+type testS3Storage struct {
+	Type   string `json:"type"`
+	Bucket string `json:"bucket"`
+}
+
+func (s testS3Storage) Location() string { return "s3://" + s.Bucket }
+
+type testDiskStorage struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+}
+
+func (s *testDiskStorage) Location() string { return s.Path }
+
+func TestRegisterPolymorphic(t *testing.T) {
+	RegisterPolymorphic("storage", "type", map[string]reflect.Type{
+		"s3":   reflect.TypeOf(testS3Storage{}),
+		"disk": reflect.TypeOf(&testDiskStorage{}),
+	})
+	defer UnregisterPolymorphic("storage")
+
+	type Config struct {
+		Storage testStorage `ssm:"storage"`
+		Backup  interface{} `ssm:"backup" json:"true"`
+	}
+
+	t.Run("instantiates the type selected by the discriminator", func(t *testing.T) {
+		values := map[string]string{"storage": `{"type":"s3","bucket":"configs"}`}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		require.IsType(t, testS3Storage{}, result.Storage)
+		assert.Equal(t, "s3://configs", result.Storage.Location())
+	})
+
+	t.Run("allocates registered pointer types", func(t *testing.T) {
+		values := map[string]string{"storage": `{"type":"disk","path":"/var/data"}`}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		require.IsType(t, &testDiskStorage{}, result.Storage)
+		assert.Equal(t, "/var/data", result.Storage.Location())
+	})
+
+	t.Run("unregistered interface fields still decode to maps", func(t *testing.T) {
+		values := map[string]string{"backup": `{"type":"s3"}`}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"type": "s3"}, result.Backup)
+	})
+
+	t.Run("reports unknown discriminator values", func(t *testing.T) {
+		values := map[string]string{"storage": `{"type":"gcs"}`}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown type "gcs" (expected one of: disk, s3)`)
+	})
+
+	t.Run("reports missing discriminator", func(t *testing.T) {
+		values := map[string]string{"storage": `{"bucket":"configs"}`}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `missing discriminator property "type"`)
+	})
+
+	t.Run("reports types that don't implement the interface", func(t *testing.T) {
+		RegisterPolymorphic("storage", "type", map[string]reflect.Type{
+			"disk": reflect.TypeOf(testDiskStorage{}), // Location has a pointer receiver
+		})
+
+		values := map[string]string{"storage": `{"type":"disk"}`}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not implement")
+	})
+}
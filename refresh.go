@@ -3,7 +3,10 @@ package ssmconfig
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/signal"
 	"reflect"
+	"slices"
 	"sync"
 	"time"
 )
@@ -19,6 +22,14 @@ type RefreshingConfig[T any] struct {
 	cancel          context.CancelFunc
 	wg              sync.WaitGroup
 	onChange        func(oldConfig, newConfig *T)
+	onFieldChange   func(changes []FieldChange)
+	strictRefresh   bool
+	onRefreshError  func(err error)
+	lastErr         error
+	clock           clock            // Source of refresh ticks; the real clock unless replaced in tests
+	incremental     bool             // If true, refreshes only re-map when an SSM parameter changed
+	stamps          []parameterStamp // Stamps of the parameters the current config was mapped from
+	reloadSignals   []os.Signal      // Signals that trigger a refresh, in addition to the timer
 }
 
 // RefreshingConfigOption configures a RefreshingConfig.
@@ -39,6 +50,64 @@ func WithOnChange[T any](callback func(oldConfig, newConfig *T)) RefreshingConfi
 	}
 }
 
+// WithOnFieldChange sets a callback function that is called with the changed values (see Diff)
+// when a refresh changes the configuration. It's called after the WithOnChange callback, and
+// not at all if only unexported fields changed.
+func WithOnFieldChange[T any](callback func(changes []FieldChange)) RefreshingConfigOption[T] {
+	return func(rc *RefreshingConfig[T]) {
+		rc.onFieldChange = callback
+	}
+}
+
+// WithRefreshStrictMode controls whether a strict-mode panic during refresh is propagated.
+// Loads only panic when the loader uses WithStrictPanic(true). By default (false), a refresh
+// returns the strict-mode failure as an error instead, so the previous configuration is kept.
+// Strictness of the initial load is controlled by WithStrictMode.
+func WithRefreshStrictMode[T any](strict bool) RefreshingConfigOption[T] {
+	return func(rc *RefreshingConfig[T]) {
+		rc.strictRefresh = strict
+	}
+}
+
+// withClock replaces the clock driving refreshes, so tests can trigger them without sleeping.
+func withClock[T any](c clock) RefreshingConfigOption[T] {
+	return func(rc *RefreshingConfig[T]) {
+		rc.clock = c
+	}
+}
+
+// WithOnRefreshError sets a callback function that is called when a refresh fails.
+// The previous configuration is kept when this happens.
+func WithOnRefreshError[T any](callback func(err error)) RefreshingConfigOption[T] {
+	return func(rc *RefreshingConfig[T]) {
+		rc.onRefreshError = callback
+	}
+}
+
+// WithIncrementalRefresh makes each refresh re-map the configuration only if an SSM parameter
+// was modified, added, or deleted since the current configuration was loaded. Parameters are
+// still fetched on every refresh, since that's how their LastModifiedDate is read, but mapping,
+// validation, and the change comparison are skipped when nothing changed. Changes are detected
+// by comparing LastModifiedDate values reported by SSM with each other, never with the local
+// clock, so this relies on the accuracy of SSM's clock. Changes to config files, Vault secrets,
+// or environment variables alone don't trigger a re-map; parameters without a LastModifiedDate
+// (e.g. from a snapshot) are always re-mapped.
+func WithIncrementalRefresh[T any](enabled bool) RefreshingConfigOption[T] {
+	return func(rc *RefreshingConfig[T]) {
+		rc.incremental = enabled
+	}
+}
+
+// WithReloadOnSignal refreshes the configuration whenever the process receives sig (e.g.
+// syscall.SIGHUP), in addition to the periodic refreshes, so operators can force a reload
+// without an API. The signal handler is removed by Stop. Can be given several times to
+// reload on several signals.
+func WithReloadOnSignal[T any](sig os.Signal) RefreshingConfigOption[T] {
+	return func(rc *RefreshingConfig[T]) {
+		rc.reloadSignals = append(rc.reloadSignals, sig)
+	}
+}
+
 // LoadWithAutoRefresh loads configuration and starts auto-refreshing it periodically.
 func LoadWithAutoRefresh[T any](
 	ctx context.Context, prefix string, opts ...LoaderOption) (*RefreshingConfig[T], error) {
@@ -69,12 +138,16 @@ func LoadWithAutoRefreshAndLoader[T any](
 		refreshInterval: 5 * time.Minute, // Default 5 minutes
 		ctx:             refreshCtx,
 		cancel:          cancel,
+		clock:           realClock{},
 	}
 
 	// Apply options
 	for _, opt := range opts {
 		opt(rc)
 	}
+	if rc.incremental {
+		rc.stamps = loader.parameterStamps(prefix)
+	}
 
 	// Start auto-refresh
 	rc.start()
@@ -91,8 +164,18 @@ func (rc *RefreshingConfig[T]) Get() *T {
 	return rc.config
 }
 
+// GetImmutable returns the current configuration without copying it.
+// Every refresh stores a newly loaded struct and never modifies a published one, so the
+// returned value stays unchanged for as long as it's held, even across refreshes. Callers
+// must treat it as read-only; it's intended for read-heavy paths where GetCopy is too costly.
+func (rc *RefreshingConfig[T]) GetImmutable() *T {
+	return rc.Get()
+}
+
 // GetCopy returns a deep copy of the current configuration.
 // This is safe to modify without affecting the original.
+// Unexported struct fields are copied shallowly: values such as time.Time are kept intact,
+// but pointers, slices, and maps held in unexported fields are shared with the original.
 func (rc *RefreshingConfig[T]) GetCopy() (*T, error) {
 	rc.mu.RLock()
 	defer rc.mu.RUnlock()
@@ -136,11 +219,13 @@ func copyValue(src, dst reflect.Value) error {
 	case reflect.Float32, reflect.Float64:
 		dst.SetFloat(src.Float())
 		return nil
+	case reflect.Complex64, reflect.Complex128:
+		dst.SetComplex(src.Complex())
+		return nil
 	case reflect.String:
 		dst.SetString(src.String())
 		return nil
-	case reflect.Uintptr, reflect.Complex64, reflect.Complex128, reflect.Array,
-		reflect.Chan, reflect.Func, reflect.UnsafePointer:
+	case reflect.Uintptr, reflect.Chan, reflect.Func, reflect.UnsafePointer:
 		return fmt.Errorf("unsupported kind for copying: %v", src.Kind())
 	case reflect.Ptr:
 		if src.IsNil() {
@@ -161,8 +246,19 @@ func copyValue(src, dst reflect.Value) error {
 		dst.Set(copiedValue)
 
 	case reflect.Struct:
+		// Unexported fields can't be set via reflection, so a struct that has them is first
+		// copied as a whole value, keeping state such as a time.Time's (shallowly). Exported
+		// fields are then deep copied over it.
+		if dst.CanSet() && hasUnexportedFields(src.Type()) {
+			dst.Set(src)
+		}
 		for i := 0; i < src.NumField(); i++ {
-			if err := copyValue(src.Field(i), dst.Field(i)); err != nil {
+			dstField := dst.Field(i)
+			// Structs are still traversed so exported fields of embedded types get copied
+			if !dstField.CanSet() && dstField.Kind() != reflect.Struct {
+				continue
+			}
+			if err := copyValue(src.Field(i), dstField); err != nil {
 				return err
 			}
 		}
@@ -178,6 +274,13 @@ func copyValue(src, dst reflect.Value) error {
 			}
 		}
 
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			if err := copyValue(src.Index(i), dst.Index(i)); err != nil {
+				return err
+			}
+		}
+
 	case reflect.Map:
 		if src.IsNil() {
 			return nil
@@ -203,31 +306,111 @@ func copyValue(src, dst reflect.Value) error {
 	return nil
 }
 
+// hasUnexportedFields reports whether a struct type has fields that reflection can't set.
+func hasUnexportedFields(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// LastError returns the error of the most recent refresh, or nil if it succeeded.
+func (rc *RefreshingConfig[T]) LastError() error {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.lastErr
+}
+
 // Refresh manually triggers a refresh of the configuration.
 // This bypasses the cache to ensure fresh values are loaded from SSM.
+// On failure the previous configuration is kept and the error is returned.
+// A successful refresh replaces the configuration with a new struct; the previous one
+// is never modified (see GetImmutable).
 func (rc *RefreshingConfig[T]) Refresh() error {
 	// Invalidate cache first to ensure we get fresh values
 	rc.loader.InvalidateCache(rc.prefix)
+	if rc.loader.fallbackPrefix != "" {
+		rc.loader.InvalidateCache(rc.loader.fallbackPrefix)
+	}
 
-	newConfig, err := LoadWithLoader[T](rc.loader, rc.ctx, rc.prefix)
+	var stamps []parameterStamp
+	if rc.incremental {
+		var unchanged bool
+		var err error
+		stamps, unchanged, err = rc.checkUnchanged()
+		if err != nil {
+			return rc.refreshFailed(err)
+		}
+		if unchanged {
+			rc.mu.Lock()
+			rc.lastErr = nil
+			rc.mu.Unlock()
+			return nil
+		}
+	}
+
+	newConfig, err := rc.load()
 	if err != nil {
-		return err
+		return rc.refreshFailed(err)
 	}
 
 	rc.mu.Lock()
 	oldConfig := rc.config
 	hasChanged := !reflect.DeepEqual(oldConfig, newConfig)
 	rc.config = newConfig
+	rc.lastErr = nil
+	rc.stamps = stamps
 	rc.mu.Unlock()
 
 	// Notify of change if callback is set and config actually changed
 	if rc.onChange != nil && hasChanged {
 		rc.onChange(oldConfig, newConfig)
 	}
+	if rc.onFieldChange != nil && hasChanged {
+		if changes := Diff(oldConfig, newConfig); len(changes) > 0 {
+			rc.onFieldChange(changes)
+		}
+	}
 
 	return nil
 }
 
+// refreshFailed records a refresh error, keeping the previous configuration, and returns it.
+func (rc *RefreshingConfig[T]) refreshFailed(err error) error {
+	rc.mu.Lock()
+	rc.lastErr = err
+	rc.mu.Unlock()
+
+	if rc.onRefreshError != nil {
+		rc.onRefreshError(err)
+	}
+	return err
+}
+
+// checkUnchanged fetches the SSM parameters, caching them for the load that may follow, and
+// reports whether they are unchanged since the current configuration was mapped.
+func (rc *RefreshingConfig[T]) checkUnchanged() ([]parameterStamp, bool, error) {
+	if _, err := rc.loader.loadSSMValues(rc.ctx, rc.prefix); err != nil {
+		return nil, false, err
+	}
+	stamps := rc.loader.parameterStamps(rc.prefix)
+
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return stamps, stamps != nil && slices.EqualFunc(stamps, rc.stamps, parameterStamp.equal), nil
+}
+
+// load reloads the configuration. Unless strictRefresh is set, a strict-mode failure is
+// returned as an error even if the loader uses WithStrictPanic. Other panics propagate.
+func (rc *RefreshingConfig[T]) load() (*T, error) {
+	if rc.strictRefresh || !rc.loader.strictPanic {
+		return LoadWithLoader[T](rc.loader, rc.ctx, rc.prefix)
+	}
+	return loadMerged[T](rc.loader, rc.ctx, []string{rc.prefix}, false)
+}
+
 // Stop stops the auto-refresh goroutine.
 func (rc *RefreshingConfig[T]) Stop() {
 	rc.cancel()
@@ -236,20 +419,36 @@ func (rc *RefreshingConfig[T]) Stop() {
 
 // start begins the auto-refresh goroutine.
 func (rc *RefreshingConfig[T]) start() {
+	c := rc.clock
+	if c == nil {
+		c = realClock{}
+	}
+
+	// The ticker (and signal handler) are set up before the goroutine starts so no tick
+	// or signal is missed
+	ticker := c.NewTicker(rc.refreshInterval)
+	var signals chan os.Signal // Stays nil, and so never ready, without reload signals
+	if len(rc.reloadSignals) > 0 {
+		signals = make(chan os.Signal, 1)
+		signal.Notify(signals, rc.reloadSignals...)
+	}
 	rc.wg.Add(1)
 	go func() {
 		defer rc.wg.Done()
-		ticker := time.NewTicker(rc.refreshInterval)
 		defer ticker.Stop()
+		if signals != nil {
+			defer signal.Stop(signals)
+		}
 
 		for {
 			select {
 			case <-rc.ctx.Done():
 				return
-			case <-ticker.C:
-				if err := rc.Refresh(); err != nil && rc.loader.logger != nil {
-					rc.loader.logger("Error refreshing config: %v", err)
-				}
+			case <-ticker.C():
+			case <-signals:
+			}
+			if err := rc.Refresh(); err != nil && rc.loader.logger != nil {
+				rc.loader.logger("Error refreshing config: %v", err)
 			}
 		}
 	}()
@@ -2,9 +2,12 @@ package ssmconfig
 
 import (
 	"context"
+	"errors"
 	"os"
 	"reflect"
+	"runtime"
 	"sync"
+	"syscall"
 	"testing"
 	"time"
 
@@ -240,6 +243,139 @@ func TestRefreshingConfig_Refresh(t *testing.T) {
 	})
 }
 
+func TestRefreshingConfig_GetImmutable(t *testing.T) {
+	type Config struct {
+		Value string   `ssm:"value"`
+		Tags  []string `ssm:"tags"`
+	}
+
+	client := newMockSSMClient(map[string]string{"/test/value": "old", "/test/tags": "a,b"})
+	loader := newLoader(client)
+
+	rc, err := LoadWithAutoRefreshAndLoader[Config](loader, context.Background(), "/test/",
+		WithRefreshInterval[Config](time.Hour))
+	require.NoError(t, err)
+	defer rc.Stop()
+
+	before := rc.GetImmutable()
+	assert.Same(t, rc.Get(), before)
+
+	client.setParameter("/test/value", "new")
+	client.setParameter("/test/tags", "c")
+	require.NoError(t, rc.Refresh())
+
+	after := rc.GetImmutable()
+	assert.NotSame(t, before, after)
+	assert.Equal(t, "new", after.Value)
+	assert.Equal(t, []string{"c"}, after.Tags)
+
+	// The pointer handed out before the refresh still sees the old values
+	assert.Equal(t, "old", before.Value)
+	assert.Equal(t, []string{"a", "b"}, before.Tags)
+}
+
+func TestWithIncrementalRefresh(t *testing.T) {
+	type Config struct {
+		Host string `ssm:"host"`
+		Port string `ssm:"port"`
+	}
+
+	modified := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newClient := func() *mockSSMClient {
+		client := newMockSSMClient(map[string]string{})
+		client.setParameterAt("/test/host", "db.example.com", modified)
+		client.setParameterAt("/test/port", "5432", modified)
+		return client
+	}
+	load := func(t *testing.T, client *mockSSMClient, incremental bool) *RefreshingConfig[Config] {
+		rc, err := LoadWithAutoRefreshAndLoader[Config](newLoader(client), context.Background(), "/test/",
+			WithRefreshInterval[Config](time.Hour), WithIncrementalRefresh[Config](incremental))
+		require.NoError(t, err)
+		t.Cleanup(rc.Stop)
+		return rc
+	}
+
+	t.Run("skips re-mapping when nothing was modified", func(t *testing.T) {
+		client := newClient()
+		rc := load(t, client, true)
+		before := rc.Get()
+
+		require.NoError(t, rc.Refresh())
+		assert.Same(t, before, rc.Get())
+		// The check's fetch is reused, so each refresh still calls SSM once
+		assert.Equal(t, 2, client.callCount())
+	})
+
+	t.Run("re-maps when a parameter was modified", func(t *testing.T) {
+		client := newClient()
+		rc := load(t, client, true)
+
+		client.setParameterAt("/test/host", "new.example.com", modified.Add(time.Second))
+		require.NoError(t, rc.Refresh())
+		assert.Equal(t, "new.example.com", rc.Get().Host)
+		assert.Equal(t, 2, client.callCount())
+
+		before := rc.Get()
+		require.NoError(t, rc.Refresh())
+		assert.Same(t, before, rc.Get())
+	})
+
+	t.Run("re-maps when a parameter was deleted", func(t *testing.T) {
+		client := newClient()
+		rc := load(t, client, true)
+
+		client.deleteParameter("/test/port")
+		require.NoError(t, rc.Refresh())
+		assert.Empty(t, rc.Get().Port)
+	})
+
+	t.Run("re-maps parameters without modification dates", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/test/host": "db.example.com"})
+		rc := load(t, client, true)
+		before := rc.Get()
+
+		require.NoError(t, rc.Refresh())
+		assert.NotSame(t, before, rc.Get())
+	})
+
+	t.Run("re-maps on every refresh when disabled", func(t *testing.T) {
+		rc := load(t, newClient(), false)
+		before := rc.Get()
+
+		require.NoError(t, rc.Refresh())
+		assert.NotSame(t, before, rc.Get())
+	})
+
+	t.Run("modification dates survive the cache backend", func(t *testing.T) {
+		backend := NewMemoryCache()
+		first := newLoader(newClient(), WithCacheBackend(backend))
+		second := newLoader(newClient(), WithCacheBackend(backend))
+
+		_, err := first.loadByPrefix(context.Background(), "/test/")
+		require.NoError(t, err)
+		_, err = second.loadByPrefix(context.Background(), "/test/")
+		require.NoError(t, err)
+
+		assert.Equal(t, []parameterStamp{{newest: modified, count: 2}}, first.parameterStamps("/test/"))
+		stamps := second.parameterStamps("/test/")
+		require.Len(t, stamps, 1)
+		assert.True(t, stamps[0].equal(parameterStamp{newest: modified, count: 2}))
+	})
+
+	t.Run("fetch errors are refresh errors", func(t *testing.T) {
+		client := newClient()
+		rc := load(t, client, true)
+		before := rc.Get()
+
+		client.mu.Lock()
+		client.err = errors.New("access denied")
+		client.mu.Unlock()
+		require.Error(t, rc.Refresh())
+		assert.Error(t, rc.LastError())
+		assert.Same(t, before, rc.Get())
+	})
+}
+
 func TestWithRefreshInterval(t *testing.T) {
 	t.Run("sets refresh interval", func(t *testing.T) {
 		type Config struct {
@@ -275,6 +411,30 @@ func TestWithOnChange(t *testing.T) {
 	})
 }
 
+func TestWithOnFieldChange(t *testing.T) {
+	type Config struct {
+		Host string `ssm:"host"`
+		Port int    `ssm:"port"`
+	}
+
+	client := newMockSSMClient(map[string]string{"/myapp/host": "db1", "/myapp/port": "5432"})
+	var notified [][]FieldChange
+	rc, err := LoadWithAutoRefreshAndLoader[Config](newLoader(client), context.Background(), "/myapp/",
+		WithRefreshInterval[Config](time.Hour),
+		WithOnFieldChange[Config](func(changes []FieldChange) {
+			notified = append(notified, changes)
+		}))
+	require.NoError(t, err)
+	defer rc.Stop()
+
+	require.NoError(t, rc.Refresh())
+	assert.Empty(t, notified, "an unchanged refresh isn't reported")
+
+	client.setParameter("/myapp/host", "db2")
+	require.NoError(t, rc.Refresh())
+	assert.Equal(t, [][]FieldChange{{{Path: "Host", OldValue: "db1", NewValue: "db2"}}}, notified)
+}
+
 func TestDeepCopy(t *testing.T) {
 	t.Run("copies simple struct", func(t *testing.T) {
 		type Config struct {
@@ -375,4 +535,239 @@ func TestDeepCopy(t *testing.T) {
 			assert.NotEqual(t, original.Metadata["key"], testValueModified, "Should be a copy, not a reference")
 		}
 	})
+
+	t.Run("copies complex fields", func(t *testing.T) {
+		type Config struct {
+			Impedance complex128
+			Pole      complex64
+			Zeros     []complex128
+		}
+
+		original := &Config{Impedance: 1 + 2i, Pole: -0.5 - 3i, Zeros: []complex128{1i, 2}}
+		copyConfig, err := deepCopy(original)
+		require.NoError(t, err)
+		require.NotNil(t, copyConfig)
+		assert.Equal(t, original, copyConfig)
+		assert.NotSame(t, original, copyConfig)
+	})
+}
+
+func TestRefreshingConfig_RefreshStrictMode(t *testing.T) {
+	type Config struct {
+		Value string `ssm:"value" required:"true"`
+	}
+
+	t.Run("recovers strict mode panic and keeps previous config", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/test/value": "initial"})
+		loader := newLoader(client, WithStrictMode(true))
+
+		var callbackErr error
+		rc, err := LoadWithAutoRefreshAndLoader[Config](loader, context.Background(), "/test/",
+			WithRefreshInterval[Config](time.Hour),
+			WithOnRefreshError[Config](func(err error) {
+				callbackErr = err
+			}))
+		require.NoError(t, err)
+		defer rc.Stop()
+
+		client.deleteParameter("/test/value")
+
+		assert.NotPanics(t, func() {
+			err = rc.Refresh()
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Missing required fields")
+		assert.ErrorIs(t, err, ErrMissingRequired)
+		assert.Equal(t, "initial", rc.Get().Value)
+		assert.Equal(t, err, callbackErr)
+		assert.Equal(t, err, rc.LastError())
+	})
+
+	t.Run("clears last error after successful refresh", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/test/value": "initial"})
+		loader := newLoader(client, WithStrictMode(true))
+
+		rc, err := LoadWithAutoRefreshAndLoader[Config](loader, context.Background(), "/test/",
+			WithRefreshInterval[Config](time.Hour))
+		require.NoError(t, err)
+		defer rc.Stop()
+
+		client.deleteParameter("/test/value")
+		require.Error(t, rc.Refresh())
+
+		client.setParameter("/test/value", "updated")
+		require.NoError(t, rc.Refresh())
+		assert.NoError(t, rc.LastError())
+		assert.Equal(t, "updated", rc.Get().Value)
+	})
+
+	t.Run("recovers strict panics of the loader", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/test/value": "initial"})
+		loader := newLoader(client, WithStrictMode(true), WithStrictPanic(true))
+
+		rc, err := LoadWithAutoRefreshAndLoader[Config](loader, context.Background(), "/test/",
+			WithRefreshInterval[Config](time.Hour))
+		require.NoError(t, err)
+		defer rc.Stop()
+
+		client.deleteParameter("/test/value")
+		assert.NotPanics(t, func() {
+			err = rc.Refresh()
+		})
+		assert.ErrorIs(t, err, ErrMissingRequired)
+		assert.Equal(t, "initial", rc.Get().Value)
+	})
+
+	t.Run("propagates panic when refresh strict mode is enabled", func(t *testing.T) {
+		client := newMockSSMClient(map[string]string{"/test/value": "initial"})
+		loader := newLoader(client, WithStrictMode(true), WithStrictPanic(true))
+
+		rc, err := LoadWithAutoRefreshAndLoader[Config](loader, context.Background(), "/test/",
+			WithRefreshInterval[Config](time.Hour),
+			WithRefreshStrictMode[Config](true))
+		require.NoError(t, err)
+		defer rc.Stop()
+
+		client.deleteParameter("/test/value")
+		assert.Panics(t, func() {
+			_ = rc.Refresh()
+		})
+	})
+}
+
+func TestDeepCopy_Arrays(t *testing.T) {
+	t.Run("copies array fields", func(t *testing.T) {
+		type Config struct {
+			Mask [4]byte
+		}
+
+		original := &Config{Mask: [4]byte{255, 255, 255, 0}}
+		copyConfig, err := deepCopy(original)
+		require.NoError(t, err)
+		require.NotNil(t, copyConfig)
+		assert.Equal(t, [4]byte{255, 255, 255, 0}, copyConfig.Mask)
+
+		copyConfig.Mask[0] = 10
+		assert.Equal(t, byte(255), original.Mask[0], "Should be a copy, not a reference")
+	})
+
+	t.Run("copies arrays of pointers", func(t *testing.T) {
+		type Config struct {
+			Values [2]*string
+		}
+
+		first := "first"
+		original := &Config{Values: [2]*string{&first, nil}}
+		copyConfig, err := deepCopy(original)
+		require.NoError(t, err)
+		require.NotNil(t, copyConfig.Values[0])
+		assert.Equal(t, "first", *copyConfig.Values[0])
+		assert.NotSame(t, original.Values[0], copyConfig.Values[0])
+		assert.Nil(t, copyConfig.Values[1])
+	})
+}
+
+func TestDeepCopy_UnexportedFields(t *testing.T) {
+	t.Run("copies unexported fields with the struct", func(t *testing.T) {
+		type Config struct {
+			Value   string
+			private string
+		}
+
+		original := &Config{Value: "test", private: "secret"}
+		copyConfig, err := deepCopy(original)
+		require.NoError(t, err)
+		require.NotNil(t, copyConfig)
+		assert.Equal(t, "test", copyConfig.Value)
+		assert.Equal(t, "secret", copyConfig.private)
+	})
+
+	t.Run("keeps values with private state", func(t *testing.T) {
+		type Config struct {
+			At       time.Time
+			Deadline *time.Time
+			History  []time.Time
+		}
+
+		now := time.Now()
+		deadline := now.Add(time.Hour)
+		original := &Config{At: now, Deadline: &deadline, History: []time.Time{now.Add(-time.Hour)}}
+		copyConfig, err := deepCopy(original)
+		require.NoError(t, err)
+		require.NotNil(t, copyConfig)
+		assert.True(t, copyConfig.At.Equal(now))
+		assert.Equal(t, original, copyConfig)
+		assert.NotSame(t, original.Deadline, copyConfig.Deadline)
+	})
+
+	t.Run("copies exported fields of embedded unexported struct", func(t *testing.T) {
+		type embedded struct {
+			Host    string
+			counter int
+		}
+		type Config struct {
+			embedded
+			Port int
+		}
+
+		original := &Config{embedded: embedded{Host: "localhost", counter: 3}, Port: 8080}
+		copyConfig, err := deepCopy(original)
+		require.NoError(t, err)
+		require.NotNil(t, copyConfig)
+		assert.Equal(t, "localhost", copyConfig.Host)
+		assert.Equal(t, 8080, copyConfig.Port)
+		assert.Equal(t, 3, copyConfig.counter)
+	})
+
+	t.Run("deep copies exported fields of structs with unexported fields", func(t *testing.T) {
+		type Config struct {
+			Hosts []string
+			state int
+		}
+
+		original := &Config{Hosts: []string{"a"}, state: 1}
+		copyConfig, err := deepCopy(original)
+		require.NoError(t, err)
+		copyConfig.Hosts[0] = testValueModified
+		assert.Equal(t, "a", original.Hosts[0])
+		assert.Equal(t, 1, copyConfig.state)
+	})
+
+	t.Run("GetCopy succeeds with unexported fields", func(t *testing.T) {
+		type Config struct {
+			Value string
+			mu    sync.Mutex
+		}
+
+		rc := &RefreshingConfig[Config]{config: &Config{Value: "test"}}
+		cfgCopy, err := rc.GetCopy()
+		require.NoError(t, err)
+		assert.Equal(t, "test", cfgCopy.Value)
+	})
+}
+
+func TestWithReloadOnSignal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals can't be sent to the process on Windows")
+	}
+	type Config struct {
+		Host string `ssm:"host"`
+	}
+	client := newMockSSMClient(map[string]string{})
+	client.setParameterAt("/test/host", "db.example.com", time.Time{})
+
+	rc, err := LoadWithAutoRefreshAndLoader[Config](newLoader(client), context.Background(), "/test/",
+		WithRefreshInterval[Config](time.Hour), WithReloadOnSignal[Config](syscall.SIGHUP))
+	require.NoError(t, err)
+	defer rc.Stop()
+
+	client.setParameterAt("/test/host", "new.example.com", time.Time{})
+	process, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, process.Signal(syscall.SIGHUP))
+
+	assert.Eventually(t, func() bool {
+		return rc.Get().Host == "new.example.com"
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 2, client.callCount())
 }
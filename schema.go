@@ -0,0 +1,171 @@
+package ssmconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// jsonSchemaDraft is the JSON Schema dialect produced by GenerateSchema.
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchema is the subset of JSON Schema used to describe configuration parameters.
+type jsonSchema struct {
+	Schema           string                 `json:"$schema,omitempty"`
+	Type             string                 `json:"type,omitempty"`
+	Format           string                 `json:"format,omitempty"`
+	ContentMediaType string                 `json:"contentMediaType,omitempty"`
+	Properties       map[string]*jsonSchema `json:"properties,omitempty"`
+	Required         []string               `json:"required,omitempty"`
+	Items            *jsonSchema            `json:"items,omitempty"`
+	Enum             []string               `json:"enum,omitempty"`
+	Minimum          *float64               `json:"minimum,omitempty"`
+	Maximum          *float64               `json:"maximum,omitempty"`
+	MinLength        *int                   `json:"minLength,omitempty"`
+	MaxLength        *int                   `json:"maxLength,omitempty"`
+}
+
+// GenerateSchema produces a JSON Schema describing the parameters expected by T.
+// Properties are keyed by the ssm tag (nested structs use the same prefix as the mapper),
+// the required list comes from required tags, and the validate tag contributes
+// enum (oneof), minimum/maximum (min/max), minLength/maxLength (minlen/maxlen), and format (email/url).
+// Fields decoded from JSON (json:"true") are described as strings with an application/json media type.
+// Of the options, only WithTagName has an effect, so keys are read from the same tag as in Load.
+func GenerateSchema[T any](opts ...LoaderOption) ([]byte, error) {
+	var zero T
+	t := reflect.TypeOf(zero)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("type must be a struct")
+	}
+
+	schema := structSchema(t, newLoader(nil, opts...).tagName)
+	schema.Schema = jsonSchemaDraft
+
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// structSchema builds the schema for a struct type by walking its tagged fields.
+// Keys are read from the tagName tag ("ssm" if empty).
+func structSchema(t reflect.Type, tagName string) *jsonSchema {
+	schema := &jsonSchema{
+		Type:       "object",
+		Properties: make(map[string]*jsonSchema),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue // Skip unexported fields
+		}
+
+		ssmTag, squash := parseSSMTag(field, tagName)
+		jsonTag := field.Tag.Get("json")
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+
+		var key string
+		var property *jsonSchema
+		isJSON := jsonTag == jsonTagTrue || jsonTag == jsonTagOne || jsonTag == jsonTagYes
+
+		switch {
+		case isJSON:
+			if ssmTag == "" {
+				continue
+			}
+			key = ssmTag
+			property = &jsonSchema{Type: "string", ContentMediaType: "application/json"}
+		case fieldType.Kind() == reflect.Struct && !hasDecoder(field.Type) && squash:
+			// Squashed struct fields are described at the parent level
+			squashed := structSchema(fieldType, tagName)
+			for name, property := range squashed.Properties {
+				schema.Properties[name] = property
+			}
+			schema.Required = append(schema.Required, squashed.Required...)
+			continue
+		case fieldType.Kind() == reflect.Struct && !hasDecoder(field.Type):
+			key = ssmTag
+			if key == "" {
+				key = strings.ToLower(field.Name)
+			}
+			property = structSchema(fieldType, tagName)
+		default:
+			if ssmTag == "" {
+				continue
+			}
+			key = ssmTag
+			property = typeSchema(fieldType, tagName)
+		}
+
+		applyValidateSchema(property, field.Tag.Get("validate"))
+		schema.Properties[key] = property
+
+		if isRequiredField(field.Tag.Get("required")) {
+			schema.Required = append(schema.Required, key)
+		}
+	}
+
+	return schema
+}
+
+// typeSchema returns the schema for a non-struct field type. Struct elements of slices read
+// their keys from the tagName tag.
+func typeSchema(t reflect.Type, tagName string) *jsonSchema {
+	//nolint:exhaustive // Unsupported kinds are described as strings
+	switch t.Kind() {
+	case reflect.Bool:
+		return &jsonSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &jsonSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &jsonSchema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		elem := t.Elem()
+		if elem.Kind() == reflect.Ptr {
+			elem = elem.Elem()
+		}
+		if elem.Kind() == reflect.Struct {
+			return &jsonSchema{Type: "array", Items: structSchema(elem, tagName)}
+		}
+		return &jsonSchema{Type: "array", Items: typeSchema(elem, tagName)}
+	case reflect.Map:
+		return &jsonSchema{Type: "object"}
+	default:
+		return &jsonSchema{Type: "string"}
+	}
+}
+
+// applyValidateSchema adds constraints derived from the validate tag to the schema.
+func applyValidateSchema(schema *jsonSchema, validateTag string) {
+	for _, spec := range strings.Split(validateTag, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(spec), ":")
+		switch name {
+		case "email":
+			schema.Format = "email"
+		case "url":
+			schema.Format = "uri"
+		case "oneof":
+			schema.Enum = strings.Fields(params)
+		case "min":
+			if v, err := strconv.ParseFloat(params, 64); err == nil {
+				schema.Minimum = &v
+			}
+		case "max":
+			if v, err := strconv.ParseFloat(params, 64); err == nil {
+				schema.Maximum = &v
+			}
+		case "minlen":
+			if v, err := strconv.Atoi(params); err == nil {
+				schema.MinLength = &v
+			}
+		case "maxlen":
+			if v, err := strconv.Atoi(params); err == nil {
+				schema.MaxLength = &v
+			}
+		}
+	}
+}
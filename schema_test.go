@@ -0,0 +1,108 @@
+package ssmconfig
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSchema(t *testing.T) {
+	type Database struct {
+		Host string `ssm:"host" required:"true"`
+		Port int    `ssm:"port" validate:"min:1,max:65535"`
+	}
+	type Feature struct {
+		Name string `json:"name"`
+	}
+	type Config struct {
+		Name     string    `ssm:"name" required:"true" validate:"minlen:3,maxlen:20"`
+		Level    string    `ssm:"level" validate:"oneof:debug info warn"`
+		Email    string    `ssm:"email" validate:"email"`
+		Ratio    float64   `ssm:"ratio"`
+		Debug    bool      `ssm:"debug"`
+		Hosts    []string  `ssm:"hosts"`
+		Features []Feature `ssm:"features" json:"true"`
+		Database Database  `ssm:"database" required:"true"`
+		EnvOnly  string    `env:"ENV_ONLY"`
+	}
+
+	data, err := GenerateSchema[Config]()
+	require.NoError(t, err)
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &schema))
+
+	t.Run("describes the root object", func(t *testing.T) {
+		assert.Equal(t, jsonSchemaDraft, schema["$schema"])
+		assert.Equal(t, "object", schema["type"])
+		assert.ElementsMatch(t, []interface{}{"name", "database"}, schema["required"])
+	})
+
+	properties := schema["properties"].(map[string]interface{})
+
+	t.Run("maps field types", func(t *testing.T) {
+		assert.Equal(t, "string", properties["name"].(map[string]interface{})["type"])
+		assert.Equal(t, "number", properties["ratio"].(map[string]interface{})["type"])
+		assert.Equal(t, "boolean", properties["debug"].(map[string]interface{})["type"])
+
+		hosts := properties["hosts"].(map[string]interface{})
+		assert.Equal(t, "array", hosts["type"])
+		assert.Equal(t, "string", hosts["items"].(map[string]interface{})["type"])
+
+		features := properties["features"].(map[string]interface{})
+		assert.Equal(t, "string", features["type"])
+		assert.Equal(t, "application/json", features["contentMediaType"])
+	})
+
+	t.Run("derives constraints from validate tags", func(t *testing.T) {
+		name := properties["name"].(map[string]interface{})
+		assert.Equal(t, float64(3), name["minLength"])
+		assert.Equal(t, float64(20), name["maxLength"])
+
+		level := properties["level"].(map[string]interface{})
+		assert.Equal(t, []interface{}{"debug", "info", "warn"}, level["enum"])
+
+		assert.Equal(t, "email", properties["email"].(map[string]interface{})["format"])
+	})
+
+	t.Run("describes nested structs", func(t *testing.T) {
+		database := properties["database"].(map[string]interface{})
+		assert.Equal(t, "object", database["type"])
+		assert.Equal(t, []interface{}{"host"}, database["required"])
+
+		port := database["properties"].(map[string]interface{})["port"].(map[string]interface{})
+		assert.Equal(t, "integer", port["type"])
+		assert.Equal(t, float64(1), port["minimum"])
+		assert.Equal(t, float64(65535), port["maximum"])
+	})
+
+	t.Run("skips fields without ssm tag", func(t *testing.T) {
+		_, ok := properties["ENV_ONLY"]
+		assert.False(t, ok)
+		assert.Len(t, properties, 8)
+	})
+
+	t.Run("reads keys from the WithTagName tag", func(t *testing.T) {
+		type Tagged struct {
+			Host  string `cfg:"host" required:"true"`
+			Items []struct {
+				Name string `cfg:"name"`
+			} `cfg:"items"`
+		}
+		data, err := GenerateSchema[Tagged](WithTagName("cfg"))
+		require.NoError(t, err)
+		var tagged map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &tagged))
+
+		assert.Equal(t, []interface{}{"host"}, tagged["required"])
+		items := tagged["properties"].(map[string]interface{})["items"].(map[string]interface{})
+		assert.Contains(t, items["items"].(map[string]interface{})["properties"], "name")
+	})
+
+	t.Run("rejects non-struct types", func(t *testing.T) {
+		_, err := GenerateSchema[string]()
+		assert.Error(t, err)
+	})
+}
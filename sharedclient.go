@@ -0,0 +1,59 @@
+package ssmconfig
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+)
+
+// sharedClientEnv lists the environment variables the default AWS configuration reads the
+// region and credentials from. Loaders created with the same values share a client.
+var sharedClientEnv = []string{
+	"AWS_REGION",
+	"AWS_DEFAULT_REGION",
+	"AWS_PROFILE",
+	"AWS_DEFAULT_PROFILE",
+	"AWS_ACCESS_KEY_ID",
+	"AWS_SESSION_TOKEN",
+	"AWS_ROLE_ARN",
+	"AWS_WEB_IDENTITY_TOKEN_FILE",
+	"AWS_CONFIG_FILE",
+	"AWS_SHARED_CREDENTIALS_FILE",
+}
+
+// sharedClients caches the SSM clients of loaders created with WithSharedClient(true),
+// keyed by sharedClientKey.
+var sharedClients = struct {
+	mu      sync.Mutex
+	clients map[string]ssmAPI
+}{clients: make(map[string]ssmAPI)}
+
+// sharedClientKey identifies the region and credentials the default AWS configuration
+// would be loaded with.
+func sharedClientKey() string {
+	parts := make([]string, len(sharedClientEnv))
+	for i, name := range sharedClientEnv {
+		parts[i] = name + "=" + os.Getenv(name)
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// sharedClient returns the cached SSM client for the current region and credentials,
+// creating it with newClient on first use. Failures aren't cached.
+func sharedClient(ctx context.Context, newClient func(context.Context) (ssmAPI, error)) (ssmAPI, error) {
+	key := sharedClientKey()
+
+	sharedClients.mu.Lock()
+	defer sharedClients.mu.Unlock()
+
+	if client, ok := sharedClients.clients[key]; ok {
+		return client, nil
+	}
+	client, err := newClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sharedClients.clients[key] = client
+	return client, nil
+}
@@ -0,0 +1,77 @@
+package ssmconfig
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// resetSharedClients empties the shared client cache when the test ends.
+func resetSharedClients(t *testing.T) {
+	t.Cleanup(func() {
+		sharedClients.mu.Lock()
+		defer sharedClients.mu.Unlock()
+		clear(sharedClients.clients)
+	})
+}
+
+func TestWithSharedClient(t *testing.T) {
+	t.Run("creates one client per region and credentials", func(t *testing.T) {
+		resetSharedClients(t)
+		created := 0
+		newClient := func(context.Context) (ssmAPI, error) {
+			created++
+			return newMockSSMClient(nil), nil
+		}
+
+		t.Setenv("AWS_REGION", "us-east-1")
+		first, err := sharedClient(context.Background(), newClient)
+		require.NoError(t, err)
+		second, err := sharedClient(context.Background(), newClient)
+		require.NoError(t, err)
+		assert.Same(t, first, second)
+		assert.Equal(t, 1, created)
+
+		t.Setenv("AWS_REGION", "eu-west-1")
+		other, err := sharedClient(context.Background(), newClient)
+		require.NoError(t, err)
+		assert.NotSame(t, first, other)
+		assert.Equal(t, 2, created)
+	})
+
+	t.Run("doesn't cache failures", func(t *testing.T) {
+		resetSharedClients(t)
+		failing := func(context.Context) (ssmAPI, error) {
+			return nil, errors.New("no credentials")
+		}
+
+		_, err := sharedClient(context.Background(), failing)
+		require.Error(t, err)
+
+		client, err := sharedClient(context.Background(), func(context.Context) (ssmAPI, error) {
+			return newMockSSMClient(nil), nil
+		})
+		require.NoError(t, err)
+		assert.NotNil(t, client)
+	})
+
+	t.Run("NewLoader reuses the client only when opted in", func(t *testing.T) {
+		resetSharedClients(t)
+		t.Setenv("AWS_REGION", "us-east-1")
+		ctx := context.Background()
+
+		first, err := NewLoader(ctx, WithSharedClient(true))
+		require.NoError(t, err)
+		second, err := NewLoader(ctx, WithSharedClient(true), WithStrictMode(true))
+		require.NoError(t, err)
+		assert.Same(t, first.ssmClient, second.ssmClient)
+		assert.True(t, second.strict)
+
+		unshared, err := NewLoader(ctx)
+		require.NoError(t, err)
+		assert.NotSame(t, first.ssmClient, unshared.ssmClient)
+	})
+}
@@ -0,0 +1,130 @@
+package ssmconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// snapshotParameterList is the output shape of `aws ssm get-parameters-by-path`,
+// also accepted as a snapshot file.
+type snapshotParameterList struct {
+	Parameters []struct {
+		Name  string `json:"Name"`
+		Value string `json:"Value"`
+	} `json:"Parameters"`
+}
+
+// DumpSnapshot loads all parameters under the given prefixes and writes them to path
+// as a JSON object mapping full parameter names to values, e.g.:
+//
+//	{
+//	  "/myapp/database/host": "db.example.com",
+//	  "/myapp/port": "8080"
+//	}
+//
+// The file can be used with WithSnapshotFile. SecureString values are written decrypted,
+// so treat snapshot files as secrets.
+func (l *Loader) DumpSnapshot(ctx context.Context, path string, prefixes ...string) error {
+	snapshot := make(map[string]string)
+	for _, prefix := range prefixes {
+		params, err := l.fetchParameters(ctx, l.ssmPath(prefix))
+		if err != nil {
+			return err
+		}
+		for name, value := range params {
+			snapshot[name] = value
+		}
+	}
+
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding snapshot: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing snapshot %s: %w", path, err)
+	}
+	return nil
+}
+
+// readSnapshot returns the parameters under prefix from a snapshot file, keyed by full name.
+// The file is either a JSON object of name -> value (as written by DumpSnapshot) or the
+// {"Parameters": [{"Name": ..., "Value": ...}]} output of the AWS CLI.
+func readSnapshot(path, prefix string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading snapshot %s: %w", path, err)
+	}
+
+	params := make(map[string]string)
+	var list snapshotParameterList
+	if err := json.Unmarshal(data, &list); err == nil && list.Parameters != nil {
+		for _, p := range list.Parameters {
+			params[p.Name] = p.Value
+		}
+	} else if err := json.Unmarshal(data, &params); err != nil {
+		return nil, fmt.Errorf("decoding snapshot %s: %w", path, err)
+	}
+
+	// Match the SSM path hierarchy: "/myapp" covers "/myapp/..." but not "/myapp2/..."
+	pathPrefix := strings.TrimSuffix(prefix, "/") + "/"
+	out := make(map[string]string)
+	for name, value := range params {
+		if strings.HasPrefix(name, pathPrefix) {
+			out[name] = value
+		}
+	}
+	return out, nil
+}
+
+// record adds the parameters fetched for prefix to the recording and rewrites the record file.
+func (l *Loader) record(prefix string, params map[string]string) error {
+	l.recordMu.Lock()
+	defer l.recordMu.Unlock()
+
+	if l.recording == nil {
+		l.recording = make(map[string]map[string]string)
+	}
+	l.recording[prefix] = params
+
+	data, err := json.MarshalIndent(l.recording, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding recording: %w", err)
+	}
+	if err := os.WriteFile(l.recordFile, data, 0o600); err != nil {
+		return fmt.Errorf("writing recording %s: %w", l.recordFile, err)
+	}
+	return nil
+}
+
+// readRecording returns the parameters recorded for prefix (a normalized SSM path) by WithRecord.
+// Recordings made before prefixes were normalized are keyed by the prefix as it was passed to the
+// load, so those spellings are tried too.
+func readRecording(path, prefix string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading recording %s: %w", path, err)
+	}
+
+	var recording map[string]map[string]string
+	if err := json.Unmarshal(data, &recording); err != nil {
+		return nil, fmt.Errorf("decoding recording %s: %w", path, err)
+	}
+
+	for _, key := range recordingKeys(prefix) {
+		if params, ok := recording[key]; ok {
+			return params, nil
+		}
+	}
+	return nil, fmt.Errorf("no recorded parameters for prefix %s in %s", prefix, path)
+}
+
+// recordingKeys returns the keys a recording may use for a normalized prefix such as "/myapp":
+// the prefix itself, then the legacy spellings "/myapp/", "myapp", and "myapp/".
+func recordingKeys(prefix string) []string {
+	name := strings.TrimPrefix(prefix, "/")
+	return []string{prefix, prefix + "/", name, name + "/"}
+}
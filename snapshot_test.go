@@ -0,0 +1,176 @@
+package ssmconfig
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSnapshotFile(t *testing.T) {
+	type Config struct {
+		Host string `ssm:"database/host"`
+		Port int    `ssm:"port" env:"SNAPSHOT_TEST_PORT"`
+	}
+
+	t.Run("loads from name-value map without calling SSM", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{
+			"/myapp/database/host": "snapshot-host",
+			"/myapp/port": "8080",
+			"/myapp2/port": "9090"
+		}`), 0o600))
+
+		client := newMockSSMClient(map[string]string{"/myapp/port": "1"})
+		loader := newLoader(client, WithSnapshotFile(path))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp")
+		require.NoError(t, err)
+		assert.Equal(t, "snapshot-host", cfg.Host)
+		assert.Equal(t, 8080, cfg.Port)
+		assert.Equal(t, 0, client.callCount())
+	})
+
+	t.Run("loads from AWS CLI parameter list", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{
+			"Parameters": [
+				{"Name": "/myapp/database/host", "Type": "String", "Value": "cli-host", "Version": 1}
+			]
+		}`), 0o600))
+
+		loader := newLoader(nil, WithSnapshotFile(path))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "cli-host", cfg.Host)
+	})
+
+	t.Run("environment overrides snapshot values", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"/myapp/port": "8080"}`), 0o600))
+		t.Setenv("SNAPSHOT_TEST_PORT", "9000")
+
+		loader := newLoader(nil, WithSnapshotFile(path))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, 9000, cfg.Port)
+	})
+
+	t.Run("returns error for missing or invalid file", func(t *testing.T) {
+		loader := newLoader(nil, WithSnapshotFile(filepath.Join(t.TempDir(), "missing.json")))
+		_, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reading snapshot")
+
+		path := filepath.Join(t.TempDir(), "invalid.json")
+		require.NoError(t, os.WriteFile(path, []byte(`not json`), 0o600))
+		loader = newLoader(nil, WithSnapshotFile(path))
+		_, err = LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "decoding snapshot")
+	})
+}
+
+func TestLoader_DumpSnapshot(t *testing.T) {
+	type Config struct {
+		Host string `ssm:"database/host"`
+		Port int    `ssm:"port"`
+	}
+
+	client := newMockSSMClient(map[string]string{
+		"/myapp/database/host": "db.example.com",
+		"/myapp/port":          "8080",
+		"/shared/region":       "us-east-1",
+		"/other/ignored":       "x",
+	})
+	live := newLoader(client)
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, live.DumpSnapshot(context.Background(), path, "/myapp/", "/shared/"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"/myapp/database/host": "db.example.com",
+		"/myapp/port": "8080",
+		"/shared/region": "us-east-1"
+	}`, string(data))
+
+	// The dumped file round-trips through WithSnapshotFile
+	offline := newLoader(nil, WithSnapshotFile(path))
+	cfg, err := LoadWithLoader[Config](offline, context.Background(), "/myapp/")
+	require.NoError(t, err)
+	assert.Equal(t, "db.example.com", cfg.Host)
+	assert.Equal(t, 8080, cfg.Port)
+}
+
+func TestWithRecordAndReplay(t *testing.T) {
+	type Config struct {
+		Host   string `ssm:"database/host"`
+		Port   int    `ssm:"port"`
+		Region string `ssm:"region"`
+	}
+
+	path := filepath.Join(t.TempDir(), "recording.json")
+
+	client := newMockSSMClient(map[string]string{
+		"/myapp/database/host": "db.example.com",
+		"/myapp/port":          "8080",
+		"/defaults/region":     "us-east-1",
+	})
+	recorder := newLoader(client, WithRecord(path), WithFallbackPrefix("/defaults/"))
+
+	recorded, err := LoadWithLoader[Config](recorder, context.Background(), "/myapp/")
+	require.NoError(t, err)
+
+	t.Run("records parameters per prefix", func(t *testing.T) {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"/defaults": {"/defaults/region": "us-east-1"},
+			"/myapp": {
+				"/myapp/database/host": "db.example.com",
+				"/myapp/port": "8080"
+			}
+		}`, string(data))
+	})
+
+	t.Run("replays without calling SSM", func(t *testing.T) {
+		replayClient := newMockSSMClient(map[string]string{})
+		replayer := newLoader(replayClient, WithReplay(path), WithFallbackPrefix("/defaults/"))
+
+		replayed, err := LoadWithLoader[Config](replayer, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, recorded, replayed)
+		assert.Equal(t, 0, replayClient.callCount())
+	})
+
+	t.Run("fails for prefixes missing from the recording", func(t *testing.T) {
+		replayer := newLoader(nil, WithReplay(path))
+
+		_, err := LoadWithLoader[Config](replayer, context.Background(), "/other/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no recorded parameters for prefix /other")
+	})
+
+	t.Run("replays recordings keyed by the prefix as passed", func(t *testing.T) {
+		legacy := filepath.Join(t.TempDir(), "legacy.json")
+		require.NoError(t, os.WriteFile(legacy, []byte(`{
+			"defaults/": {"/defaults/region": "us-east-1"},
+			"/myapp/": {
+				"/myapp/database/host": "db.example.com",
+				"/myapp/port": "8080"
+			}
+		}`), 0o600))
+		replayer := newLoader(nil, WithReplay(legacy), WithFallbackPrefix("/defaults/"))
+
+		replayed, err := LoadWithLoader[Config](replayer, context.Background(), "myapp")
+		require.NoError(t, err)
+		assert.Equal(t, recorded, replayed)
+	})
+}
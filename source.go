@@ -0,0 +1,161 @@
+package ssmconfig
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ValueSource identifies where a field's value came from.
+type ValueSource string
+
+// Value sources. SourceEnv and SourceSSM are also the names accepted by the source tag,
+// where ssm covers the loaded values map (file and Vault values as well as SSM parameters).
+const (
+	SourceNone ValueSource = ""     // No source provided a value
+	SourceEnv  ValueSource = "env"  // Environment variable (including the _FILE convention)
+	SourceFile ValueSource = "file" // Config file or raw config document
+	SourceSSM  ValueSource = "ssm"  // SSM Parameter Store (or a snapshot/replay of it)
+
+	SourceVault   ValueSource = "vault"   // HashiCorp Vault KV secret (WithVault)
+	SourceDefault ValueSource = "default" // The field's default tag, used when no source had a value
+)
+
+// defaultSources is the lookup order used when a field has no source tag: ENV > File > SSM.
+var defaultSources = []ValueSource{SourceEnv, SourceSSM}
+
+// parseSourceTag returns the lookup order named by a source tag (e.g. source:"ssm,env").
+// An empty tag yields the default order. Sources left out of the tag are never consulted.
+func parseSourceTag(tag, fieldName string) ([]ValueSource, error) {
+	if tag == "" {
+		return defaultSources, nil
+	}
+
+	var sources []ValueSource
+	seen := make(map[ValueSource]bool)
+	for _, part := range strings.Split(tag, ",") {
+		source := ValueSource(strings.ToLower(strings.TrimSpace(part)))
+		if source != SourceEnv && source != SourceSSM {
+			return nil, fmt.Errorf("unknown source '%s' for field %s (want %s or %s)",
+				part, fieldName, SourceEnv, SourceSSM)
+		}
+		if seen[source] {
+			return nil, fmt.Errorf("duplicate source '%s' for field %s", source, fieldName)
+		}
+		seen[source] = true
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
+// lookupValue returns the first present value for a field, trying sources in order.
+// fromValues reports whether the value came from the values map rather than the environment.
+// An error is returned only when an env value file (the _FILE convention) can't be read.
+func (m *mapper) lookupValue(sources []ValueSource, values map[string]string, ssmTag, envTag, fieldName string,
+	present func(string) bool) (val string, hasValue, fromValues bool, err error) {
+	for _, source := range sources {
+		switch source {
+		case SourceEnv:
+			if envTag != "" {
+				envVal, err := m.lookupEnv(envTag, fieldName)
+				if err != nil {
+					return "", false, false, err
+				}
+				if present(envVal) {
+					return envVal, true, false, nil
+				}
+			}
+		case SourceSSM:
+			if ssmTag != "" {
+				if ssmVal, exists := values[ssmTag]; exists && present(ssmVal) {
+					return ssmVal, true, true, nil
+				}
+			}
+		}
+	}
+	return "", false, false, nil
+}
+
+// lookupEnv returns the value of the first set environment variable named by the env tag, which
+// may list several (env:"APP_DB_URL,DATABASE_URL"). With the _FILE convention enabled, an unset
+// variable FOO falls back to the contents of the file named by FOO_FILE before the next is tried.
+func (m *mapper) lookupEnv(envTag, fieldName string) (string, error) {
+	for _, name := range m.envNames(envTag) {
+		if val := os.Getenv(name); val != "" {
+			return val, nil
+		}
+		if !m.opts.fileEnv {
+			continue
+		}
+		if path := os.Getenv(name + fileEnvSuffix); path != "" {
+			val, err := readValueFile(m.opts.fsys, path, fieldName)
+			if err != nil || val != "" {
+				return val, err
+			}
+		}
+	}
+	return "", nil
+}
+
+// envSet reports whether any environment variable named by the env tag (or, with the _FILE
+// convention, its _FILE variant) is set.
+func (m *mapper) envSet(envTag string) bool {
+	for _, name := range m.envNames(envTag) {
+		if os.Getenv(name) != "" || (m.opts.fileEnv && os.Getenv(name+fileEnvSuffix) != "") {
+			return true
+		}
+	}
+	return false
+}
+
+// envNames returns the environment variables named by an env tag, in lookup order.
+func envNames(envTag string) []string {
+	var names []string
+	for _, name := range strings.Split(envTag, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// envNames returns the environment variables named by an env tag, with the env prefix of
+// WithGlobalPrefix prepended to each.
+func (m *mapper) envNames(envTag string) []string {
+	names := envNames(envTag)
+	if m.opts.envPrefix == "" {
+		return names
+	}
+	for i, name := range names {
+		names[i] = m.opts.envPrefix + name
+	}
+	return names
+}
+
+// envOverrides reports whether the field's environment variable takes precedence over its
+// SSM key, i.e. it is set and env comes before ssm in the source order.
+func (m *mapper) envOverrides(sources []ValueSource, envTag string) bool {
+	if !m.envSet(envTag) {
+		return false
+	}
+	for _, source := range sources {
+		switch source {
+		case SourceEnv:
+			return true
+		case SourceSSM:
+			return false
+		}
+	}
+	return false
+}
+
+// nonEmpty reports whether a value is present for the strongly-typed path.
+func nonEmpty(val string) bool {
+	return val != ""
+}
+
+// nonBlank reports whether a value is present for JSON-decoded nested structs,
+// where whitespace-only values are treated as missing.
+func nonBlank(val string) bool {
+	return strings.TrimSpace(val) != ""
+}
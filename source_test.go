@@ -0,0 +1,176 @@
+package ssmconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapToStruct_SourceTag(t *testing.T) {
+	values := map[string]string{
+		"default":   "from-ssm",
+		"ssm_only":  "from-ssm",
+		"ssm_first": "from-ssm",
+		"env_first": "from-ssm",
+		"fallback":  "from-ssm",
+	}
+
+	type Config struct {
+		Default  string `ssm:"default" env:"TEST_SOURCE_DEFAULT"`
+		SSMOnly  string `ssm:"ssm_only" env:"TEST_SOURCE_SSM_ONLY" source:"ssm"`
+		SSMFirst string `ssm:"ssm_first" env:"TEST_SOURCE_SSM_FIRST" source:"ssm,env"`
+		EnvFirst string `ssm:"env_first" env:"TEST_SOURCE_ENV_FIRST" source:"env,ssm"`
+		EnvOnly  string `ssm:"env_only" env:"TEST_SOURCE_ENV_ONLY" source:"env"`
+		Fallback string `ssm:"missing" env:"TEST_SOURCE_FALLBACK" source:"ssm,env"`
+	}
+
+	t.Setenv("TEST_SOURCE_DEFAULT", "from-env")
+	t.Setenv("TEST_SOURCE_SSM_ONLY", "from-env")
+	t.Setenv("TEST_SOURCE_SSM_FIRST", "from-env")
+	t.Setenv("TEST_SOURCE_ENV_FIRST", "from-env")
+	t.Setenv("TEST_SOURCE_FALLBACK", "from-env")
+
+	var cfg Config
+	require.NoError(t, mapToStruct(values, &cfg, false, nil, true))
+
+	assert.Equal(t, "from-env", cfg.Default)
+	assert.Equal(t, "from-ssm", cfg.SSMOnly)
+	assert.Equal(t, "from-ssm", cfg.SSMFirst)
+	assert.Equal(t, "from-env", cfg.EnvFirst)
+	assert.Empty(t, cfg.EnvOnly)
+	assert.Equal(t, "from-env", cfg.Fallback)
+}
+
+func TestMapToStruct_SourceTagSSMOnlyIgnoresEnvForRequired(t *testing.T) {
+	type Config struct {
+		Token string `ssm:"token" env:"TEST_SOURCE_TOKEN" source:"ssm" required:"true"`
+	}
+	t.Setenv("TEST_SOURCE_TOKEN", "from-env")
+
+	var cfg Config
+	assert.PanicsWithValue(t,
+		"ssmconfig: Missing required fields: field 'Token' (ssm:'token', env:'TEST_SOURCE_TOKEN')",
+		func() { _ = mapToStruct(map[string]string{}, &cfg, true, nil, true) })
+}
+
+func TestMapToStruct_SourceTagJSONNestedStruct(t *testing.T) {
+	type Database struct {
+		Host string `json:"host"`
+	}
+	type Config struct {
+		Database Database `ssm:"database" env:"TEST_SOURCE_DATABASE" json:"true" source:"ssm,env"`
+	}
+	t.Setenv("TEST_SOURCE_DATABASE", `{"host":"env.example.com"}`)
+
+	var cfg Config
+	require.NoError(t, mapToStruct(map[string]string{"database": `{"host":"ssm.example.com"}`}, &cfg, false, nil, true))
+	assert.Equal(t, "ssm.example.com", cfg.Database.Host)
+
+	cfg = Config{}
+	require.NoError(t, mapToStruct(map[string]string{}, &cfg, false, nil, true))
+	assert.Equal(t, "env.example.com", cfg.Database.Host)
+}
+
+func TestMapToStruct_SourceTagStructSlice(t *testing.T) {
+	type Item struct {
+		Name string `ssm:"name"`
+	}
+	type Config struct {
+		Items []Item `ssm:"items" env:"TEST_SOURCE_ITEMS" source:"ssm,env"`
+	}
+	t.Setenv("TEST_SOURCE_ITEMS", `[{"Name":"env"}]`)
+
+	var cfg Config
+	require.NoError(t, mapToStruct(map[string]string{"items/0/name": "ssm"}, &cfg, false, nil, true))
+	assert.Equal(t, []Item{{Name: "ssm"}}, cfg.Items)
+}
+
+func TestMapToStruct_SourceTagInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		wantErr string
+	}{
+		{name: "unknown source", tag: "vault", wantErr: "unknown source 'vault' for field Value"},
+		{name: "duplicate source", tag: "env,env", wantErr: "duplicate source 'env' for field Value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources, err := parseSourceTag(tt.tag, "Value")
+			require.Error(t, err)
+			assert.Nil(t, sources)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	type Config struct {
+		Value string `ssm:"value" source:"file"`
+	}
+	var cfg Config
+	err := mapToStruct(map[string]string{"value": "x"}, &cfg, false, nil, true)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown source 'file' for field Value")
+}
+
+func TestMapToStruct_EnvDefaultFallbackChain(t *testing.T) {
+	type Config struct {
+		URL string `ssm:"url" env:"TEST_CHAIN_PRIMARY, TEST_CHAIN_SECONDARY" default:"http://localhost" required:"true"`
+	}
+	type RequiredConfig struct {
+		URL string `ssm:"url" env:"TEST_CHAIN_PRIMARY,TEST_CHAIN_SECONDARY" required:"true"`
+	}
+	ssmValues := map[string]string{"url": "http://ssm"}
+
+	t.Run("first env var wins", func(t *testing.T) {
+		t.Setenv("TEST_CHAIN_PRIMARY", "http://primary")
+		t.Setenv("TEST_CHAIN_SECONDARY", "http://secondary")
+
+		var cfg Config
+		require.NoError(t, mapToStruct(ssmValues, &cfg, true, nil, true))
+		assert.Equal(t, "http://primary", cfg.URL)
+	})
+
+	t.Run("falls back to the second env var", func(t *testing.T) {
+		t.Setenv("TEST_CHAIN_PRIMARY", "")
+		t.Setenv("TEST_CHAIN_SECONDARY", "http://secondary")
+
+		var cfg Config
+		require.NoError(t, mapToStruct(ssmValues, &cfg, true, nil, true))
+		assert.Equal(t, "http://secondary", cfg.URL)
+	})
+
+	t.Run("then SSM", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, mapToStruct(ssmValues, &cfg, true, nil, true))
+		assert.Equal(t, "http://ssm", cfg.URL)
+	})
+
+	t.Run("then the default, which satisfies required", func(t *testing.T) {
+		var cfg Config
+		require.NoError(t, mapToStruct(map[string]string{"url": ""}, &cfg, true, nil, true))
+		assert.Equal(t, "http://localhost", cfg.URL)
+		assert.NoError(t, ValidateRequiredFields[Config](map[string]string{}, nil))
+	})
+
+	t.Run("errors only when every source is empty and there is no default", func(t *testing.T) {
+		var cfg RequiredConfig
+		assert.PanicsWithValue(t,
+			"ssmconfig: Missing required fields: field 'URL' (ssm:'url', env:'TEST_CHAIN_PRIMARY,TEST_CHAIN_SECONDARY')",
+			func() { _ = mapToStruct(map[string]string{}, &cfg, true, nil, true) })
+		assert.Error(t, ValidateRequiredFields[RequiredConfig](map[string]string{}, nil))
+
+		t.Setenv("TEST_CHAIN_SECONDARY", "http://secondary")
+		assert.NoError(t, ValidateRequiredFields[RequiredConfig](map[string]string{}, nil))
+	})
+
+	t.Run("explain reports the default source", func(t *testing.T) {
+		reports, err := ExplainWithLoader[Config](newLoader(newMockSSMClient(nil)), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		require.Len(t, reports, 1)
+		assert.Equal(t, SourceDefault, reports[0].Source)
+		assert.True(t, reports[0].Valid)
+	})
+}
@@ -0,0 +1,37 @@
+package ssmconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// parameterTypeStringList is the SSM type of comma-separated list parameters.
+const parameterTypeStringList = string(types.ParameterTypeStringList)
+
+// setStringList sets a slice field from the comma-separated value of an SSM StringList parameter.
+// Each element is converted like a scalar field, so []int works as well as []string.
+// Sets (map[string]bool, map[string]struct{}) get the list items as members, and slices of
+// pointers (e.g. []*int) get nil for empty items. Other non-slice fields are rejected unless
+// a decoder is registered for their type.
+func setStringList(fv reflect.Value, val, fieldName, key string) error {
+	if hasDecoder(fv.Type()) || isStringSet(fv.Type()) || isPointerSlice(fv.Type()) {
+		return setFieldValue(fv, val)
+	}
+	if fv.Kind() != reflect.Slice {
+		return fmt.Errorf("parameter '%s' is a StringList and can't be mapped to %s field '%s' (use a slice field)",
+			key, fv.Type(), fieldName)
+	}
+
+	parts := strings.Split(val, ",")
+	slice := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+	for i, part := range parts {
+		if err := setFieldValue(slice.Index(i), strings.TrimSpace(part)); err != nil {
+			return fmt.Errorf("setting element %d of field %s: %w", i, fieldName, err)
+		}
+	}
+	fv.Set(slice)
+	return nil
+}
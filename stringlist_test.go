@@ -0,0 +1,86 @@
+package ssmconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_StringList(t *testing.T) {
+	newClient := func() *mockSSMClient {
+		client := newMockSSMClient(map[string]string{
+			"/test/hosts":         "a.example.com, b.example.com",
+			"/test/ports":         "8080,8081",
+			"/test/database/tags": "primary,eu",
+			"/test/name":          "app",
+		})
+		client.parameterTypes = map[string]types.ParameterType{
+			"/test/hosts":         types.ParameterTypeStringList,
+			"/test/ports":         types.ParameterTypeStringList,
+			"/test/database/tags": types.ParameterTypeStringList,
+		}
+		return client
+	}
+
+	t.Run("maps StringList parameters to slices", func(t *testing.T) {
+		type Config struct {
+			Hosts    []string `ssm:"hosts" json:"true"`
+			Ports    []int    `ssm:"ports"`
+			Name     string   `ssm:"name"`
+			Database struct {
+				Tags []string `ssm:"tags"`
+			} `ssm:"database"`
+		}
+
+		cfg, err := LoadWithLoader[Config](newLoader(newClient()), context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a.example.com", "b.example.com"}, cfg.Hosts)
+		assert.Equal(t, []int{8080, 8081}, cfg.Ports)
+		assert.Equal(t, "app", cfg.Name)
+		assert.Equal(t, []string{"primary", "eu"}, cfg.Database.Tags)
+	})
+
+	t.Run("maps StringList parameters to sets", func(t *testing.T) {
+		type Config struct {
+			Hosts map[string]bool `ssm:"hosts"`
+		}
+
+		cfg, err := LoadWithLoader[Config](newLoader(newClient()), context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]bool{"a.example.com": true, "b.example.com": true}, cfg.Hosts)
+	})
+
+	t.Run("maps StringList parameters to slices of pointers", func(t *testing.T) {
+		type Config struct {
+			Ports []*int `ssm:"ports"`
+		}
+
+		cfg, err := LoadWithLoader[Config](newLoader(newClient()), context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, []*int{ToPointerValue(8080), ToPointerValue(8081)}, cfg.Ports)
+	})
+
+	t.Run("rejects StringList parameters for scalar fields", func(t *testing.T) {
+		type Config struct {
+			Hosts string `ssm:"hosts"`
+		}
+
+		_, err := LoadWithLoader[Config](newLoader(newClient()), context.Background(), "/test/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parameter 'hosts' is a StringList and can't be mapped to string field 'Hosts'")
+	})
+
+	t.Run("env overrides are mapped as regular values", func(t *testing.T) {
+		type Config struct {
+			Hosts string `ssm:"hosts" env:"TEST_STRINGLIST_HOSTS"`
+		}
+		t.Setenv("TEST_STRINGLIST_HOSTS", "c.example.com")
+
+		cfg, err := LoadWithLoader[Config](newLoader(newClient()), context.Background(), "/test/")
+		require.NoError(t, err)
+		assert.Equal(t, "c.example.com", cfg.Hosts)
+	})
+}
@@ -0,0 +1,68 @@
+package ssmconfig
+
+import "context"
+
+// Names of the spans started by the loader.
+const (
+	spanLoadFromSSM   = "ssmconfig.loadFromSSM"
+	spanLoadFromFiles = "ssmconfig.loadFromFiles"
+)
+
+// Span attribute keys set by the loader.
+const (
+	attrPrefix = "ssmconfig.prefix" // SSM prefix being loaded
+	attrPages  = "ssmconfig.pages"  // GetParametersByPath pages fetched (unset for cache hits)
+	attrFiles  = "ssmconfig.files"  // Config files and documents read
+)
+
+// Tracer starts the spans the loader wraps its SSM and config file loads in. It is the subset
+// of the OpenTelemetry trace.Tracer API the loader needs, so this module doesn't depend on
+// OpenTelemetry; ssmconfigotel.Tracer (module github.com/ali63yavari/ssmconfig/otel) adapts
+// an OTel tracer.
+type Tracer interface {
+	// Start creates a span as a child of any span in ctx and returns a context holding it.
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	// SetAttribute records a key/value pair (the values are strings and ints) on the span.
+	SetAttribute(key string, value any)
+	// RecordError records an error that occurred during the span.
+	RecordError(err error)
+	// End completes the span.
+	End()
+}
+
+// spanContextKey is the context key of the span started by startSpan.
+type spanContextKey struct{}
+
+// startSpan starts a span with the loader's tracer. Without a tracer it returns ctx unchanged
+// and a nil span, and does nothing else.
+func (l *Loader) startSpan(ctx context.Context, name string) (context.Context, Span) {
+	if l.tracer == nil {
+		return ctx, nil
+	}
+	ctx, span := l.tracer.Start(ctx, name)
+	return context.WithValue(ctx, spanContextKey{}, span), span
+}
+
+// spanFromContext returns the span started by startSpan, or nil if ctx holds none.
+func (l *Loader) spanFromContext(ctx context.Context) Span {
+	if l.tracer == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanContextKey{}).(Span)
+	return span
+}
+
+// endSpan records err, if any, on span and ends it. It does nothing for a nil span.
+func endSpan(span Span, err error) {
+	if span == nil {
+		return
+	}
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
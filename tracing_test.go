@@ -0,0 +1,143 @@
+package ssmconfig
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSpan records what the loader sets on a span.
+type fakeSpan struct {
+	name       string
+	parent     *fakeSpan
+	attributes map[string]any
+	errors     []error
+	ended      bool
+}
+
+func (s *fakeSpan) SetAttribute(key string, value any) {
+	s.attributes[key] = value
+}
+
+func (s *fakeSpan) RecordError(err error) {
+	s.errors = append(s.errors, err)
+}
+
+func (s *fakeSpan) End() {
+	s.ended = true
+}
+
+type fakeSpanKey struct{}
+
+// fakeTracer is a Tracer that keeps every span it starts.
+type fakeTracer struct {
+	mu    sync.Mutex
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, spanName string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	parent, _ := ctx.Value(fakeSpanKey{}).(*fakeSpan)
+	span := &fakeSpan{name: spanName, parent: parent, attributes: make(map[string]any)}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, fakeSpanKey{}, span), span
+}
+
+func TestWithTracer(t *testing.T) {
+	parameters := make(map[string]string)
+	for i := 0; i < 5; i++ {
+		parameters[fmt.Sprintf("/myapp/key%d", i)] = fmt.Sprintf("value%d", i)
+	}
+
+	t.Run("spans SSM loads with prefix and page count", func(t *testing.T) {
+		tracer := &fakeTracer{}
+		loader := newLoader(newMockSSMClient(parameters), WithTracer(tracer), WithPageSize(2))
+
+		ctx, parent := tracer.Start(context.Background(), "startup")
+		values, err := loader.loadFromSSM(ctx, "/myapp/")
+		require.NoError(t, err)
+		assert.Len(t, values, 5)
+
+		require.Len(t, tracer.spans, 2)
+		span := tracer.spans[1]
+		assert.Equal(t, "ssmconfig.loadFromSSM", span.name)
+		assert.Same(t, parent, span.parent)
+		assert.Equal(t, map[string]any{"ssmconfig.prefix": "/myapp/", "ssmconfig.pages": 3}, span.attributes)
+		assert.Empty(t, span.errors)
+		assert.True(t, span.ended)
+	})
+
+	t.Run("records SSM errors", func(t *testing.T) {
+		tracer := &fakeTracer{}
+		client := newMockSSMClient(parameters)
+		client.err = errors.New("access denied")
+		loader := newLoader(client, WithTracer(tracer))
+
+		_, err := loader.loadFromSSM(context.Background(), "/myapp/")
+		require.Error(t, err)
+
+		require.Len(t, tracer.spans, 1)
+		span := tracer.spans[0]
+		require.Len(t, span.errors, 1)
+		assert.ErrorIs(t, span.errors[0], client.err)
+		assert.Equal(t, 0, span.attributes["ssmconfig.pages"])
+		assert.True(t, span.ended)
+	})
+
+	t.Run("spans config file loads", func(t *testing.T) {
+		tracer := &fakeTracer{}
+		fsys := fstest.MapFS{
+			"config.yaml":     {Data: []byte("host: localhost\n")},
+			"unreadable.yaml": {Mode: fs.ModeDir}, // A directory can't be read as a file
+		}
+		loader := newLoader(newMockSSMClient(nil),
+			WithTracer(tracer), WithConfigFS(fsys), WithConfigFiles("config.yaml", "unreadable.yaml"))
+
+		values := loader.loadFromFiles(context.Background())
+		assert.Equal(t, map[string]string{"host": "localhost"}, values)
+
+		require.Len(t, tracer.spans, 1)
+		span := tracer.spans[0]
+		assert.Equal(t, "ssmconfig.loadFromFiles", span.name)
+		assert.Equal(t, 1, span.attributes["ssmconfig.files"])
+		require.Len(t, span.errors, 1)
+		assert.Contains(t, span.errors[0].Error(), "reading config file unreadable.yaml")
+		assert.True(t, span.ended)
+	})
+
+	t.Run("a full load starts one span per source", func(t *testing.T) {
+		tracer := &fakeTracer{}
+		type Config struct {
+			Key0 string `ssm:"key0"`
+		}
+		loader := newLoader(newMockSSMClient(parameters), WithTracer(tracer), WithConfigBytes("yaml", []byte("key1: x\n")))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "value0", cfg.Key0)
+
+		var names []string
+		for _, span := range tracer.spans {
+			names = append(names, span.name)
+		}
+		assert.Equal(t, []string{"ssmconfig.loadFromFiles", "ssmconfig.loadFromSSM"}, names)
+	})
+
+	t.Run("no spans without a tracer", func(t *testing.T) {
+		loader := newLoader(newMockSSMClient(parameters))
+		ctx := context.Background()
+
+		spanCtx, span := loader.startSpan(ctx, spanLoadFromSSM)
+		assert.Nil(t, span)
+		assert.Equal(t, ctx, spanCtx)
+		assert.Nil(t, loader.spanFromContext(ctx))
+	})
+}
@@ -0,0 +1,59 @@
+package ssmconfig
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// TransformFunc transforms a raw parameter value before it is converted to the field type.
+type TransformFunc func(value string) string
+
+var (
+	transforms = map[string]TransformFunc{
+		"lower":     strings.ToLower,
+		"upper":     strings.ToUpper,
+		"trim":      strings.TrimSpace,
+		"trimslash": func(value string) string { return strings.TrimRight(value, "/") },
+	}
+	transformsMu sync.RWMutex
+)
+
+// RegisterTransform registers a transform that can be used via the transform tag
+// (e.g., transform:"lower"). Built-in transforms are lower, upper, trim, and trimslash.
+func RegisterTransform(name string, transform TransformFunc) {
+	transformsMu.Lock()
+	defer transformsMu.Unlock()
+	transforms[name] = transform
+}
+
+// UnregisterTransform removes a registered transform.
+func UnregisterTransform(name string) {
+	transformsMu.Lock()
+	defer transformsMu.Unlock()
+	delete(transforms, name)
+}
+
+// GetTransform retrieves a registered transform by name.
+func GetTransform(name string) (TransformFunc, bool) {
+	transformsMu.RLock()
+	defer transformsMu.RUnlock()
+	transform, ok := transforms[name]
+	return transform, ok
+}
+
+// applyTransforms applies the comma-separated transforms from the transform tag in order.
+func applyTransforms(value, transformTag, fieldName string) (string, error) {
+	for _, name := range strings.Split(transformTag, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		transform, ok := GetTransform(name)
+		if !ok {
+			return "", fmt.Errorf("transform '%s' not found for field '%s'", name, fieldName)
+		}
+		value = transform(value)
+	}
+	return value, nil
+}
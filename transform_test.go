@@ -0,0 +1,107 @@
+package ssmconfig
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterTransform(t *testing.T) {
+	t.Run("registers and retrieves transform", func(t *testing.T) {
+		RegisterTransform("reverse", func(value string) string {
+			runes := []rune(value)
+			for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+				runes[i], runes[j] = runes[j], runes[i]
+			}
+			return string(runes)
+		})
+		defer UnregisterTransform("reverse")
+
+		transform, ok := GetTransform("reverse")
+		require.True(t, ok)
+		assert.Equal(t, "cba", transform("abc"))
+	})
+
+	t.Run("unregisters transform", func(t *testing.T) {
+		RegisterTransform("noop", func(value string) string { return value })
+		UnregisterTransform("noop")
+
+		_, ok := GetTransform("noop")
+		assert.False(t, ok)
+	})
+}
+
+func TestBuiltinTransforms(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"lower", "EU-West-1", "eu-west-1"},
+		{"upper", "info", "INFO"},
+		{"trim", "  value  ", "value"},
+		{"trimslash", "https://example.com//", "https://example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transform, ok := GetTransform(tt.name)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, transform(tt.input))
+		})
+	}
+}
+
+func TestMapToStruct_Transforms(t *testing.T) {
+	t.Run("applies transform before conversion", func(t *testing.T) {
+		type Config struct {
+			Region string `ssm:"region" transform:"lower"`
+			URL    string `ssm:"url" transform:"trimslash"`
+		}
+
+		values := map[string]string{"region": "EU-WEST-1", "url": "https://api.example.com/"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, "eu-west-1", result.Region)
+		assert.Equal(t, "https://api.example.com", result.URL)
+	})
+
+	t.Run("applies multiple transforms in order", func(t *testing.T) {
+		type Config struct {
+			Level string `ssm:"level" transform:"trim,upper"`
+		}
+
+		values := map[string]string{"level": "  debug "}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, "DEBUG", result.Level)
+	})
+
+	t.Run("transforms value before typed parsing", func(t *testing.T) {
+		type Config struct {
+			Port int `ssm:"port" transform:"trim"`
+		}
+
+		values := map[string]string{"port": " 8080 "}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, 8080, result.Port)
+	})
+
+	t.Run("returns error for unknown transform", func(t *testing.T) {
+		type Config struct {
+			Name string `ssm:"name" transform:"unknown"`
+		}
+
+		values := map[string]string{"name": "value"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.Error(t, err)
+		assert.True(t, strings.Contains(err.Error(), "transform 'unknown' not found"))
+	})
+}
@@ -0,0 +1,44 @@
+package ssmconfig
+
+import "context"
+
+// TypedLoader is a Loader bound to the config type T, for services that load one config type
+// in many places: its methods wrap LoadWithLoader, LoadWithAutoRefreshAndLoader, and MapInto
+// without repeating the type parameter at every call site.
+type TypedLoader[T any] struct {
+	loader *Loader
+}
+
+// NewTypedLoader creates a TypedLoader for T around a new Loader (see NewLoader).
+func NewTypedLoader[T any](ctx context.Context, opts ...LoaderOption) (*TypedLoader[T], error) {
+	loader, err := NewLoader(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &TypedLoader[T]{loader: loader}, nil
+}
+
+// Loader returns the underlying Loader, e.g. for single-key reads or cache invalidation.
+func (tl *TypedLoader[T]) Loader() *Loader {
+	return tl.loader
+}
+
+// Load loads the configuration under prefix into a new T, like LoadWithLoader.
+func (tl *TypedLoader[T]) Load(ctx context.Context, prefix string) (*T, error) {
+	return LoadWithLoader[T](tl.loader, ctx, prefix)
+}
+
+// AutoRefresh loads the configuration under prefix and keeps refreshing it, like
+// LoadWithAutoRefreshAndLoader.
+func (tl *TypedLoader[T]) AutoRefresh(ctx context.Context, prefix string,
+	opts ...RefreshingConfigOption[T]) (*RefreshingConfig[T], error) {
+	return LoadWithAutoRefreshAndLoader[T](tl.loader, ctx, prefix, opts...)
+}
+
+// Validate checks that values (keyed relative to the prefix, as in MapInto) map into a valid T
+// with the loader's options: required fields are present, values convert and pass their
+// validators, and the struct-level constraints hold. Nothing is fetched from SSM.
+func (tl *TypedLoader[T]) Validate(values map[string]string) error {
+	_, err := mapIntoWithLoader[T](tl.loader, values)
+	return err
+}
@@ -0,0 +1,42 @@
+package ssmconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedLoader(t *testing.T) {
+	type Config struct {
+		Host string `ssm:"host" required:"true"`
+		Port int    `ssm:"port" validate:"min:1"`
+	}
+	client := newMockSSMClient(map[string]string{"/myapp/host": "db.example.com", "/myapp/port": "5432"})
+	tl := &TypedLoader[Config]{loader: newLoader(client, WithStrictMode(true))}
+
+	t.Run("Load", func(t *testing.T) {
+		cfg, err := tl.Load(context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, &Config{Host: "db.example.com", Port: 5432}, cfg)
+	})
+
+	t.Run("AutoRefresh", func(t *testing.T) {
+		rc, err := tl.AutoRefresh(context.Background(), "/myapp/", WithRefreshInterval[Config](time.Hour))
+		require.NoError(t, err)
+		defer rc.Stop()
+		assert.Equal(t, "db.example.com", rc.Get().Host)
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		assert.NoError(t, tl.Validate(map[string]string{"host": "localhost", "port": "8080"}))
+		assert.ErrorContains(t, tl.Validate(map[string]string{"port": "8080"}), "Missing required fields")
+		assert.ErrorContains(t, tl.Validate(map[string]string{"host": "localhost", "port": "0"}), "less than minimum 1")
+	})
+
+	t.Run("Loader", func(t *testing.T) {
+		assert.Same(t, tl.loader, tl.Loader())
+	})
+}
@@ -2,6 +2,8 @@ package ssmconfig
 
 import (
 	"fmt"
+	"io/fs"
+	"maps"
 	"reflect"
 	"strconv"
 	"strings"
@@ -16,9 +18,16 @@ type ValidatorFunc func(value interface{}) error
 // The params string contains the parameters from the validate tag (e.g., "5" for minlen:5).
 type ParameterizedValidatorFunc func(value interface{}, params string) error
 
+// NormalizerFunc validates a field value and returns its canonical form.
+// The returned value is written back into the field, so it must be assignable to the field
+// type, of the same kind (e.g. a named string type for a string field), or a number that
+// converts to a numeric field without loss.
+type NormalizerFunc func(value interface{}) (interface{}, error)
+
 var (
 	validators              = make(map[string]ValidatorFunc)
 	parameterizedValidators = make(map[string]ParameterizedValidatorFunc)
+	normalizers             = make(map[string]NormalizerFunc)
 	validatorsMu            sync.RWMutex
 )
 
@@ -46,6 +55,30 @@ func UnregisterValidator(name string) {
 	delete(parameterizedValidators, name)
 }
 
+// RegisterNormalizer registers a normalizer that can be used via the validate tag
+// (e.g., validate:"trailingslash"). Unlike a validator, a normalizer returns a
+// canonicalized value that replaces the field value.
+func RegisterNormalizer(name string, normalizer NormalizerFunc) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	normalizers[name] = normalizer
+}
+
+// UnregisterNormalizer removes a registered normalizer.
+func UnregisterNormalizer(name string) {
+	validatorsMu.Lock()
+	defer validatorsMu.Unlock()
+	delete(normalizers, name)
+}
+
+// GetNormalizer retrieves a registered normalizer by name.
+func GetNormalizer(name string) (NormalizerFunc, bool) {
+	validatorsMu.RLock()
+	defer validatorsMu.RUnlock()
+	normalizer, ok := normalizers[name]
+	return normalizer, ok
+}
+
 // GetValidator retrieves a registered validator by name.
 func GetValidator(name string) (ValidatorFunc, bool) {
 	validatorsMu.RLock()
@@ -62,6 +95,28 @@ func GetParameterizedValidator(name string) (ParameterizedValidatorFunc, bool) {
 	return validator, ok
 }
 
+// validatorSet is a snapshot of the registered validators and normalizers. Each load takes one
+// before mapping, so a load sees a consistent set even while validators are registered or
+// unregistered concurrently (e.g. by a test running alongside a refresh).
+type validatorSet struct {
+	validators    map[string]ValidatorFunc
+	parameterized map[string]ParameterizedValidatorFunc
+	normalizers   map[string]NormalizerFunc
+}
+
+// snapshotValidators copies the registered validators and normalizers, registering the
+// built-in validators first if that hasn't happened yet.
+func snapshotValidators() *validatorSet {
+	ensureBuiltinValidators()
+	validatorsMu.RLock()
+	defer validatorsMu.RUnlock()
+	return &validatorSet{
+		validators:    maps.Clone(validators),
+		parameterized: maps.Clone(parameterizedValidators),
+		normalizers:   maps.Clone(normalizers),
+	}
+}
+
 // validateField validates a field value using the specified validator(s).
 // The validatorName can be:
 // - A simple name (e.g., "email")
@@ -70,7 +125,8 @@ func GetParameterizedValidator(name string) (ParameterizedValidatorFunc, bool) {
 //
 // For nested structs, this validates the entire struct object.
 // Validators on fields within nested structs are processed recursively.
-func validateField(fv reflect.Value, validatorName, fieldName string) error {
+// The path validators (pathexists, fileexists, direxists) stat paths in fsys, or on disk if it's nil.
+func (vs *validatorSet) validateField(fv reflect.Value, validatorName, fieldName string, fsys fs.FS) error {
 	if validatorName == "" {
 		return nil
 	}
@@ -109,7 +165,7 @@ func validateField(fv reflect.Value, validatorName, fieldName string) error {
 
 		// Try parameterized validator first
 		if params != "" {
-			if paramValidator, ok := GetParameterizedValidator(validatorKey); ok {
+			if paramValidator, ok := vs.parameterized[validatorKey]; ok {
 				if err := paramValidator(value, params); err != nil {
 					return fmt.Errorf("validation failed for field '%s' using validator '%s': %w", fieldName, validatorSpec, err)
 				}
@@ -118,19 +174,83 @@ func validateField(fv reflect.Value, validatorName, fieldName string) error {
 		}
 
 		// Try simple validator
-		if validator, ok := GetValidator(validatorKey); ok {
+		if validator, ok := vs.validators[validatorKey]; ok {
 			if err := validator(value); err != nil {
 				return fmt.Errorf("validation failed for field '%s' using validator '%s': %w", fieldName, validatorSpec, err)
 			}
 			continue
 		}
 
+		// Try the path validators, which check the filesystem rather than just the value
+		if _, ok := pathValidators[validatorKey]; ok {
+			if err := validatePath(fsys, validatorKey, value); err != nil {
+				return fmt.Errorf("validation failed for field '%s' using validator '%s': %w", fieldName, validatorSpec, err)
+			}
+			continue
+		}
+
+		// Try normalizer - the returned value replaces the field value
+		if normalizer, ok := vs.normalizers[validatorKey]; ok {
+			normalized, err := normalizer(value)
+			if err != nil {
+				return fmt.Errorf("validation failed for field '%s' using normalizer '%s': %w", fieldName, validatorSpec, err)
+			}
+			if err := setNormalizedValue(fv, normalized); err != nil {
+				return fmt.Errorf("normalizer '%s' for field '%s': %w", validatorSpec, fieldName, err)
+			}
+			value = normalized
+			continue
+		}
+
 		return fmt.Errorf("validator '%s' not found for field '%s'", validatorSpec, fieldName)
 	}
 
 	return nil
 }
 
+// setNormalizedValue writes a normalized value back into the field (or the value it points to).
+func setNormalizedValue(fv reflect.Value, normalized interface{}) error {
+	target := fv
+	if target.Kind() == reflect.Ptr {
+		target = target.Elem()
+	}
+
+	rv := reflect.ValueOf(normalized)
+	if !rv.IsValid() {
+		return fmt.Errorf("returned nil value")
+	}
+	switch {
+	case rv.Type().AssignableTo(target.Type()):
+		target.Set(rv)
+	case rv.Kind() == target.Kind() && rv.Type().ConvertibleTo(target.Type()):
+		target.Set(rv.Convert(target.Type()))
+	case isNumericKind(rv.Kind()) && isNumericKind(target.Kind()):
+		// Conversions that don't round-trip (overflow, dropped fractions, sign changes) are rejected
+		converted := rv.Convert(target.Type())
+		if !converted.Convert(rv.Type()).Equal(rv) {
+			return fmt.Errorf("returned %v, which doesn't fit in %v", normalized, target.Type())
+		}
+		target.Set(converted)
+	default:
+		// Other conversions are rejected, e.g. int to string, which would yield a rune ("A" for 65)
+		return fmt.Errorf("returned %T, which can't be assigned to %v", normalized, target.Type())
+	}
+	return nil
+}
+
+// isNumericKind reports whether a kind is an integer or floating-point number.
+func isNumericKind(kind reflect.Kind) bool {
+	//nolint:exhaustive // Only numeric kinds are listed
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
 var builtinValidatorsRegistered = false
 var builtinValidatorsMu sync.Mutex
 
@@ -172,34 +292,55 @@ func RegisterBuiltinValidators() {
 		return nil
 	})
 
-	// Min length validator (usage: validate:"minlen:5")
+	// Min length validator for strings, slices, maps, and arrays (usage: validate:"minlen:5")
 	RegisterParameterizedValidator("minlen", func(value interface{}, params string) error {
 		minLen, err := strconv.Atoi(params)
 		if err != nil {
 			return fmt.Errorf("invalid minlen parameter: %s", params)
 		}
-		str, ok := value.(string)
+		length, kind, ok := valueLen(value)
 		if !ok {
-			return fmt.Errorf("minlen validator requires string type")
+			return fmt.Errorf("minlen validator requires string, slice, map, or array type")
 		}
-		if len(str) < minLen {
-			return fmt.Errorf("string length %d is less than minimum %d", len(str), minLen)
+		if length < minLen {
+			return fmt.Errorf("%s length %d is less than minimum %d", kind, length, minLen)
 		}
 		return nil
 	})
 
-	// Max length validator (usage: validate:"maxlen:100")
+	// Max length validator for strings, slices, maps, and arrays (usage: validate:"maxlen:100")
 	RegisterParameterizedValidator("maxlen", func(value interface{}, params string) error {
 		maxLen, err := strconv.Atoi(params)
 		if err != nil {
 			return fmt.Errorf("invalid maxlen parameter: %s", params)
 		}
-		str, ok := value.(string)
+		length, kind, ok := valueLen(value)
 		if !ok {
-			return fmt.Errorf("maxlen validator requires string type")
+			return fmt.Errorf("maxlen validator requires string, slice, map, or array type")
 		}
-		if len(str) > maxLen {
-			return fmt.Errorf("string length %d exceeds maximum %d", len(str), maxLen)
+		if length > maxLen {
+			return fmt.Errorf("%s length %d exceeds maximum %d", kind, length, maxLen)
+		}
+		return nil
+	})
+
+	// Length range validator (usage: validate:"len:1-5", or validate:"len:3" for an exact length)
+	RegisterParameterizedValidator("len", func(value interface{}, params string) error {
+		minParam, maxParam, isRange := strings.Cut(params, "-")
+		if !isRange {
+			maxParam = minParam
+		}
+		minLen, minErr := strconv.Atoi(strings.TrimSpace(minParam))
+		maxLen, maxErr := strconv.Atoi(strings.TrimSpace(maxParam))
+		if minErr != nil || maxErr != nil || minLen < 0 || minLen > maxLen {
+			return fmt.Errorf("invalid len parameter: %s", params)
+		}
+		length, kind, ok := valueLen(value)
+		if !ok {
+			return fmt.Errorf("len validator requires string, slice, map, or array type")
+		}
+		if length < minLen || length > maxLen {
+			return fmt.Errorf("%s length %d is not within %d-%d", kind, length, minLen, maxLen)
 		}
 		return nil
 	})
@@ -232,6 +373,18 @@ func RegisterBuiltinValidators() {
 		return nil
 	})
 
+	// Allowed values validator (usage: validate:"oneof:debug info warn")
+	RegisterParameterizedValidator("oneof", func(value interface{}, params string) error {
+		allowed := strings.Fields(params)
+		str := fmt.Sprintf("%v", value)
+		for _, candidate := range allowed {
+			if str == candidate {
+				return nil
+			}
+		}
+		return fmt.Errorf("value %s is not one of [%s]", str, strings.Join(allowed, ", "))
+	})
+
 	// Max value validator for numbers (usage: validate:"max:100")
 	RegisterParameterizedValidator("max", func(value interface{}, params string) error {
 		maxVal, err := strconv.ParseFloat(params, 64)
@@ -261,6 +414,24 @@ func RegisterBuiltinValidators() {
 	})
 }
 
+// valueLen returns the length of a string, slice, map, or array value along with a word
+// describing it for error messages. ok is false for values of other kinds.
+func valueLen(value interface{}) (length int, kind string, ok bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.Len(), "string", true
+	case reflect.Slice:
+		return rv.Len(), "slice", true
+	case reflect.Array:
+		return rv.Len(), "array", true
+	case reflect.Map:
+		return rv.Len(), "map", true
+	default:
+		return 0, "", false
+	}
+}
+
 // isValidEmail performs basic email validation.
 func isValidEmail(email string) bool {
 	if len(email) < 3 {
@@ -290,3 +461,48 @@ func isValidURL(url string) bool {
 	return (len(url) >= 7 && url[0:7] == "http://") ||
 		(len(url) >= 8 && url[0:8] == "https://")
 }
+
+// checkInParam checks that the field value is in the comma-separated allowlist stored under key
+// (e.g. inparam:"config/valid_regions"). The key is relative to the load prefix, whatever the
+// nesting of the field, and the allowlist is read from the loaded values as they are, so it
+// doesn't depend on field order. Slice fields are checked element by element.
+func checkInParam(fv reflect.Value, key string, values map[string]string, fieldName string) error {
+	list, ok := values[key]
+	if !ok {
+		return fmt.Errorf("allowlist parameter '%s' for field %s is missing", key, fieldName)
+	}
+
+	var allowed []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			allowed = append(allowed, item)
+		}
+	}
+
+	check := func(v reflect.Value) error {
+		str := fmt.Sprintf("%v", v.Interface())
+		for _, candidate := range allowed {
+			if str == candidate {
+				return nil
+			}
+		}
+		return fmt.Errorf("value '%s' of field %s is not in parameter '%s' (allowed: %s)",
+			str, fieldName, key, strings.Join(allowed, ", "))
+	}
+
+	for fv.Kind() == reflect.Ptr {
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	}
+	if fv.Kind() == reflect.Slice || fv.Kind() == reflect.Array {
+		for i := 0; i < fv.Len(); i++ {
+			if err := check(fv.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return check(fv)
+}
@@ -4,6 +4,9 @@ import (
 	"errors"
 	"reflect"
 	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -106,6 +109,22 @@ func TestBuiltinValidators(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("oneof validator", func(t *testing.T) {
+		ensureBuiltinValidators()
+
+		validator, ok := GetParameterizedValidator("oneof")
+		require.True(t, ok)
+
+		err := validator("info", "debug info warn")
+		assert.NoError(t, err)
+
+		err = validator(2, "1 2 3")
+		assert.NoError(t, err)
+
+		err = validator("trace", "debug info warn")
+		assert.Error(t, err)
+	})
+
 	t.Run("minlen validator", func(t *testing.T) {
 		ensureBuiltinValidators()
 
@@ -132,6 +151,53 @@ func TestBuiltinValidators(t *testing.T) {
 		assert.Error(t, err)
 	})
 
+	t.Run("length validators count slice, map, and array elements", func(t *testing.T) {
+		ensureBuiltinValidators()
+
+		minLen, ok := GetParameterizedValidator("minlen")
+		require.True(t, ok)
+		maxLen, ok := GetParameterizedValidator("maxlen")
+		require.True(t, ok)
+
+		assert.NoError(t, minLen([]string{"a", "b"}, "2"))
+		assert.EqualError(t, minLen([]string{"a"}, "2"), "slice length 1 is less than minimum 2")
+		assert.NoError(t, maxLen(map[string]int{"a": 1}, "1"))
+		assert.EqualError(t, maxLen(map[string]int{"a": 1, "b": 2}, "1"), "map length 2 exceeds maximum 1")
+		assert.EqualError(t, minLen([2]int{}, "3"), "array length 2 is less than minimum 3")
+		assert.EqualError(t, minLen(5, "3"), "minlen validator requires string, slice, map, or array type")
+	})
+
+	t.Run("len validator", func(t *testing.T) {
+		ensureBuiltinValidators()
+
+		validator, ok := GetParameterizedValidator("len")
+		require.True(t, ok)
+
+		assert.NoError(t, validator([]int{1}, "1-5"))
+		assert.NoError(t, validator([]int{1, 2, 3, 4, 5}, "1-5"))
+		assert.EqualError(t, validator([]int{}, "1-5"), "slice length 0 is not within 1-5")
+		assert.EqualError(t, validator(map[string]string{"a": "", "b": ""}, "3"), "map length 2 is not within 3-3")
+		assert.NoError(t, validator("abc", "3"))
+		assert.EqualError(t, validator([]int{1}, "5-1"), "invalid len parameter: 5-1")
+		assert.EqualError(t, validator(true, "1"), "len validator requires string, slice, map, or array type")
+	})
+
+	t.Run("rejects a slice field that is too short", func(t *testing.T) {
+		type Config struct {
+			Hosts []string `ssm:"hosts" validate:"len:2-5"`
+		}
+
+		var result Config
+		err := mapToStruct(map[string]string{"hosts": "a"}, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(),
+			"validation failed for field 'Hosts' using validator 'len:2-5': slice length 1 is not within 2-5")
+
+		err = mapToStruct(map[string]string{"hosts": "a,b"}, &result, false, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, result.Hosts)
+	})
+
 	t.Run("min validator for numbers", func(t *testing.T) {
 		ensureBuiltinValidators()
 
@@ -171,7 +237,7 @@ func TestValidateField(t *testing.T) {
 
 		// Create a reflect.Value for testing
 		fv := reflect.ValueOf("valid")
-		err := validateField(fv, "test", "testField")
+		err := snapshotValidators().validateField(fv, "test", "testField", nil)
 		assert.NoError(t, err)
 	})
 
@@ -185,7 +251,7 @@ func TestValidateField(t *testing.T) {
 		defer UnregisterValidator("test")
 
 		fv := reflect.ValueOf("expected")
-		err := validateField(fv, "test:expected", "testField")
+		err := snapshotValidators().validateField(fv, "test:expected", "testField", nil)
 		assert.NoError(t, err)
 	})
 
@@ -200,13 +266,13 @@ func TestValidateField(t *testing.T) {
 		defer UnregisterValidator("v2")
 
 		fv := reflect.ValueOf("test")
-		err := validateField(fv, "v1,v2", "testField")
+		err := snapshotValidators().validateField(fv, "v1,v2", "testField", nil)
 		assert.NoError(t, err)
 	})
 
 	t.Run("fails on unknown validator", func(t *testing.T) {
 		fv := reflect.ValueOf("test")
-		err := validateField(fv, "unknown", "testField")
+		err := snapshotValidators().validateField(fv, "unknown", "testField", nil)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "not found")
 	})
@@ -259,3 +325,274 @@ func TestCustomValidators(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestValidatorSnapshot(t *testing.T) {
+	type Config struct {
+		First  string `ssm:"first" validate:"snapshot"`
+		Second string `ssm:"second" validate:"snapshot"`
+	}
+	values := map[string]string{"first": "a", "second": "b"}
+
+	t.Run("a load keeps the validators registered when it started", func(t *testing.T) {
+		var calls atomic.Int32
+		RegisterValidator("snapshot", func(interface{}) error {
+			calls.Add(1)
+			UnregisterValidator("snapshot") // Removed while the struct is being mapped
+			return nil
+		})
+		defer UnregisterValidator("snapshot")
+
+		var cfg Config
+		require.NoError(t, mapToStruct(values, &cfg, false, nil, true))
+		assert.Equal(t, int32(2), calls.Load())
+
+		// The next load sees the validator gone
+		err := mapToStruct(values, &cfg, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validator 'snapshot' not found for field 'First'")
+	})
+
+	t.Run("loads run safely while the registry changes", func(t *testing.T) {
+		defer UnregisterValidator("snapshot")
+		noop := func(interface{}) error { return nil }
+		RegisterValidator("snapshot", noop)
+
+		var wg sync.WaitGroup
+		stop := make(chan struct{})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					UnregisterValidator("snapshot")
+					RegisterValidator("snapshot", noop)
+				}
+			}
+		}()
+
+		for i := 0; i < 200; i++ {
+			var cfg Config
+			// Each load sees the validator either for both fields or for neither
+			if err := mapToStruct(values, &cfg, false, nil, true); err != nil {
+				assert.Contains(t, err.Error(), "field 'First'")
+			}
+		}
+		close(stop)
+		wg.Wait()
+	})
+}
+
+func TestRegisterNormalizer(t *testing.T) {
+	trailingSlash := func(value interface{}) (interface{}, error) {
+		str, ok := value.(string)
+		if !ok {
+			return nil, errors.New("trailingslash normalizer requires string type")
+		}
+		if !strings.HasSuffix(str, "/") {
+			str += "/"
+		}
+		return str, nil
+	}
+
+	t.Run("registers and retrieves normalizer", func(t *testing.T) {
+		RegisterNormalizer("trailingslash", trailingSlash)
+		defer UnregisterNormalizer("trailingslash")
+
+		normalizer, ok := GetNormalizer("trailingslash")
+		require.True(t, ok)
+		normalized, err := normalizer("https://example.com")
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/", normalized)
+	})
+
+	t.Run("writes normalized value back into field", func(t *testing.T) {
+		RegisterNormalizer("trailingslash", trailingSlash)
+		defer UnregisterNormalizer("trailingslash")
+
+		type Config struct {
+			BaseURL string `ssm:"base_url" validate:"trailingslash,url"`
+		}
+
+		values := map[string]string{"base_url": "https://api.example.com"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, "https://api.example.com/", result.BaseURL)
+	})
+
+	t.Run("replaces value before later validators run", func(t *testing.T) {
+		RegisterNormalizer("clamp", func(value interface{}) (interface{}, error) {
+			if value.(int) > 100 {
+				return 100, nil
+			}
+			return value, nil
+		})
+		defer UnregisterNormalizer("clamp")
+
+		values := map[string]string{"ratio": "150"}
+		type IntConfig struct {
+			Ratio int `ssm:"ratio" validate:"clamp,max:100"`
+		}
+		var intResult IntConfig
+		err := mapToStruct(values, &intResult, false, nil, true)
+		require.NoError(t, err)
+		assert.Equal(t, 100, intResult.Ratio)
+	})
+
+	t.Run("returns normalizer error", func(t *testing.T) {
+		RegisterNormalizer("trailingslash", trailingSlash)
+		defer UnregisterNormalizer("trailingslash")
+
+		type Config struct {
+			Port int `ssm:"port" validate:"trailingslash"`
+		}
+
+		values := map[string]string{"port": "8080"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires string type")
+	})
+
+	t.Run("rejects incompatible normalized value", func(t *testing.T) {
+		RegisterNormalizer("tostring", func(value interface{}) (interface{}, error) {
+			return []string{"x"}, nil
+		})
+		defer UnregisterNormalizer("tostring")
+
+		type Config struct {
+			Port int `ssm:"port" validate:"tostring"`
+		}
+
+		values := map[string]string{"port": "8080"}
+		var result Config
+		err := mapToStruct(values, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "can't be assigned")
+	})
+
+	t.Run("converts only between compatible types", func(t *testing.T) {
+		type Host string
+		var returned interface{}
+		RegisterNormalizer("fixed", func(interface{}) (interface{}, error) { return returned, nil })
+		defer UnregisterNormalizer("fixed")
+
+		type Config struct {
+			Name  string  `ssm:"name" validate:"fixed"`
+			Port  uint16  `ssm:"port" validate:"fixed"`
+			Ratio float64 `ssm:"ratio" validate:"fixed"`
+		}
+		mapField := func(key string, value interface{}) (Config, error) {
+			returned = value
+			var result Config
+			err := mapToStruct(map[string]string{key: "1"}, &result, false, nil, true)
+			return result, err
+		}
+
+		result, err := mapField("name", Host("db.example.com"))
+		require.NoError(t, err)
+		assert.Equal(t, "db.example.com", result.Name)
+
+		result, err = mapField("port", 8080)
+		require.NoError(t, err)
+		assert.Equal(t, uint16(8080), result.Port)
+
+		result, err = mapField("ratio", 2)
+		require.NoError(t, err)
+		assert.Equal(t, 2.0, result.Ratio)
+
+		_, err = mapField("name", 65)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "returned int, which can't be assigned to string")
+
+		_, err = mapField("port", 70000)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "returned 70000, which doesn't fit in uint16")
+
+		_, err = mapField("port", -1)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "doesn't fit in uint16")
+
+		_, err = mapField("port", 80.5)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "doesn't fit in uint16")
+	})
+
+	t.Run("is separate from validators", func(t *testing.T) {
+		RegisterNormalizer("trailingslash", trailingSlash)
+		defer UnregisterNormalizer("trailingslash")
+
+		_, ok := GetValidator("trailingslash")
+		assert.False(t, ok)
+	})
+}
+
+func TestInParamTag(t *testing.T) {
+	values := map[string]string{
+		"config/valid_regions": "us-east-1, eu-west-1",
+		"region":               "eu-west-1",
+		"replicas":             "us-east-1,ap-south-1",
+		"database/region":      "us-east-1",
+	}
+
+	t.Run("accepts values in the allowlist", func(t *testing.T) {
+		type Config struct {
+			Region   string `ssm:"region" inparam:"config/valid_regions"`
+			Database struct {
+				Region string `ssm:"region" inparam:"config/valid_regions"`
+			} `ssm:"database"`
+		}
+
+		var cfg Config
+		require.NoError(t, mapToStruct(values, &cfg, false, nil, true))
+		assert.Equal(t, "eu-west-1", cfg.Region)
+		assert.Equal(t, "us-east-1", cfg.Database.Region)
+	})
+
+	t.Run("rejects values outside the allowlist", func(t *testing.T) {
+		type Config struct {
+			Region string `ssm:"region" env:"TEST_INPARAM_REGION" inparam:"config/valid_regions"`
+		}
+		t.Setenv("TEST_INPARAM_REGION", "sa-east-1")
+
+		var cfg Config
+		err := mapToStruct(values, &cfg, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(),
+			"value 'sa-east-1' of field Region is not in parameter 'config/valid_regions' (allowed: us-east-1, eu-west-1)")
+	})
+
+	t.Run("checks each element of a slice", func(t *testing.T) {
+		type Config struct {
+			Replicas []string `ssm:"replicas" inparam:"config/valid_regions"`
+		}
+
+		var cfg Config
+		err := mapToStruct(values, &cfg, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "value 'ap-south-1' of field Replicas")
+	})
+
+	t.Run("reports a missing allowlist parameter", func(t *testing.T) {
+		type Config struct {
+			Region string `ssm:"region" inparam:"config/missing"`
+		}
+
+		var cfg Config
+		err := mapToStruct(values, &cfg, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "allowlist parameter 'config/missing' for field Region is missing")
+	})
+
+	t.Run("skips fields without a value", func(t *testing.T) {
+		type Config struct {
+			Zone string `ssm:"zone" inparam:"config/missing"`
+		}
+
+		var cfg Config
+		require.NoError(t, mapToStruct(values, &cfg, false, nil, true))
+	})
+}
@@ -0,0 +1,115 @@
+package ssmconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// vaultSecret is the HashiCorp Vault KV secret read by a loader configured with WithVault.
+type vaultSecret struct {
+	addr  string
+	path  string
+	token string
+}
+
+// WithVault merges the fields of a HashiCorp Vault KV secret into the loaded values, so the same
+// struct tags work against Vault-backed secrets. addr is the Vault server (e.g.
+// "https://vault.example.com:8200") and path the secret's API path under /v1/: "secret/data/myapp"
+// for a KV version 2 mount at secret/, or "kv/myapp" for version 1. An empty addr or token
+// falls back to the VAULT_ADDR or VAULT_TOKEN environment variable.
+//
+// Secret fields are keyed like config file keys: nested objects are flattened with slashes
+// ({"database": {"host": ...}} is "database/host"). Vault values override SSM parameters and
+// are overridden by config files and environment variables. The secret is read over Vault's
+// HTTP API on every load (it isn't cached), and a failed read fails the load.
+func WithVault(addr, path, token string) LoaderOption {
+	return func(l *Loader) {
+		l.vault = &vaultSecret{addr: addr, path: path, token: token}
+	}
+}
+
+// loadFromVault reads the configured Vault secret and flattens its fields. It returns nil
+// without a secret configured.
+func (l *Loader) loadFromVault(ctx context.Context) (map[string]string, error) {
+	if l.vault == nil {
+		return nil, nil
+	}
+
+	data, err := l.vault.read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading Vault secret %s: %w", l.vault.path, err)
+	}
+
+	values := make(map[string]string)
+	for key, value := range data {
+		flattenNestedValue(values, key, value)
+	}
+	if l.foldCase {
+		folded := make(map[string]string, len(values))
+		for key, value := range values {
+			folded[strings.ToLower(key)] = value
+		}
+		values = folded
+	}
+	return values, nil
+}
+
+// read fetches the secret and returns its fields, unwrapping the KV version 2 envelope.
+// Numbers are kept as json.Number so they convert without losing precision.
+func (s *vaultSecret) read(ctx context.Context) (map[string]interface{}, error) {
+	addr := s.addr
+	if addr == "" {
+		addr = os.Getenv("VAULT_ADDR")
+	}
+	token := s.token
+	if token == "" {
+		token = os.Getenv("VAULT_TOKEN")
+	}
+	if addr == "" {
+		return nil, fmt.Errorf("no Vault address (set one or VAULT_ADDR)")
+	}
+
+	url := strings.TrimSuffix(addr, "/") + "/v1/" + strings.TrimPrefix(s.path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var body struct {
+			Errors []string `json:"errors"`
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body)
+		if len(body.Errors) > 0 {
+			return nil, fmt.Errorf("vault returned %s: %s", resp.Status, strings.Join(body.Errors, "; "))
+		}
+		return nil, fmt.Errorf("vault returned %s", resp.Status)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding Vault response: %w", err)
+	}
+
+	// KV version 2 wraps the fields as {"data": {"data": {...}, "metadata": {...}}}
+	if inner, ok := body.Data["data"].(map[string]interface{}); ok && body.Data["metadata"] != nil {
+		return inner, nil
+	}
+	return body.Data, nil
+}
@@ -0,0 +1,118 @@
+package ssmconfig
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newVaultServer serves body for GET /v1/<path> requests carrying the token, and 403 otherwise.
+func newVaultServer(t *testing.T, path, token string, body interface{}) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(map[string][]string{"errors": {"permission denied"}})
+			return
+		}
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/"+path {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string][]string{"errors": {}})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWithVault(t *testing.T) {
+	type Config struct {
+		Host     string `ssm:"host"`
+		Password string `ssm:"password" required:"true"`
+		Port     int    `ssm:"port"`
+		Timeout  int    `ssm:"timeout"`
+		Database struct {
+			User string `ssm:"user"`
+		} `ssm:"database"`
+		Region string `ssm:"region" env:"TEST_VAULT_REGION"`
+	}
+	kv2 := map[string]interface{}{
+		"data": map[string]interface{}{
+			"data": map[string]interface{}{
+				"password": "vault-secret",
+				"port":     5433,
+				"timeout":  10,
+				"region":   "us-east-1",
+				"database": map[string]interface{}{"user": "vault-user"},
+			},
+			"metadata": map[string]interface{}{"version": 3},
+		},
+	}
+	client := newMockSSMClient(map[string]string{
+		"/myapp/host":     "ssm.example.com",
+		"/myapp/password": "ssm-secret",
+		"/myapp/port":     "5432",
+	})
+
+	t.Run("merges a KV v2 secret between SSM and config files", func(t *testing.T) {
+		t.Setenv("TEST_VAULT_REGION", "eu-west-1")
+		server := newVaultServer(t, "secret/data/myapp", "s.token", kv2)
+		loader := newLoader(client,
+			WithVault(server.URL, "secret/data/myapp", "s.token"),
+			WithConfigBytes("yaml", []byte("timeout: 30\n")))
+
+		cfg, err := LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "ssm.example.com", cfg.Host)
+		assert.Equal(t, "vault-secret", cfg.Password)
+		assert.Equal(t, 5433, cfg.Port)
+		assert.Equal(t, 30, cfg.Timeout)
+		assert.Equal(t, "vault-user", cfg.Database.User)
+		assert.Equal(t, "eu-west-1", cfg.Region)
+
+		reports, err := ExplainWithLoader[Config](loader, context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, SourceVault, reports[1].Source)
+	})
+
+	t.Run("reads a KV v1 secret", func(t *testing.T) {
+		kv1 := map[string]interface{}{"data": map[string]interface{}{"password": "v1-secret"}}
+		server := newVaultServer(t, "kv/myapp", "s.token", kv1)
+
+		cfg, err := LoadWithLoader[Config](
+			newLoader(client, WithVault(server.URL+"/", "/kv/myapp", "s.token")), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "v1-secret", cfg.Password)
+	})
+
+	t.Run("falls back to VAULT_ADDR and VAULT_TOKEN", func(t *testing.T) {
+		server := newVaultServer(t, "secret/data/myapp", "s.env-token", kv2)
+		t.Setenv("VAULT_ADDR", server.URL)
+		t.Setenv("VAULT_TOKEN", "s.env-token")
+
+		cfg, err := LoadWithLoader[Config](
+			newLoader(client, WithVault("", "secret/data/myapp", "")), context.Background(), "/myapp/")
+		require.NoError(t, err)
+		assert.Equal(t, "vault-secret", cfg.Password)
+	})
+
+	t.Run("a failed read fails the load", func(t *testing.T) {
+		server := newVaultServer(t, "secret/data/myapp", "s.token", kv2)
+
+		_, err := LoadWithLoader[Config](
+			newLoader(client, WithVault(server.URL, "secret/data/myapp", "s.wrong")), context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(),
+			"reading Vault secret secret/data/myapp: vault returned 403 Forbidden: permission denied")
+
+		_, err = LoadWithLoader[Config](
+			newLoader(client, WithVault(server.URL, "secret/data/other", "s.token")), context.Background(), "/myapp/")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "vault returned 404 Not Found")
+	})
+}
@@ -3,6 +3,7 @@ package ssmconfig
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -18,6 +19,7 @@ type ViperRemoteProvider struct {
 	loader        *Loader
 	mu            sync.RWMutex
 	values        map[string]string
+	onChange      func(changes []FieldChange)
 	ctx           context.Context
 	cancel        context.CancelFunc
 }
@@ -75,29 +77,76 @@ func (v *ViperRemoteProvider) WatchRemoteProviderOnChannel() error {
 		case <-v.ctx.Done():
 			return nil
 		case <-ticker.C:
-			if err := v.refresh(); err != nil {
+			if _, err := v.refresh(); err != nil {
 				return err
 			}
 		}
 	}
 }
 
-// refresh reloads all parameters from SSM Parameter Store.
-// This bypasses the cache to ensure fresh values are loaded.
-func (v *ViperRemoteProvider) refresh() error {
-	// Invalidate cache first to ensure we get fresh values
-	v.loader.InvalidateCache(v.path)
+// OnChange sets a callback called after a refresh that changed, added, or removed values,
+// with only the values that differ. Paths use Viper's dot notation (e.g. "database.url"),
+// and a value that was added or removed is "<absent>" on the other side. Refreshes that
+// find every value unchanged don't call it.
+func (v *ViperRemoteProvider) OnChange(callback func(changes []FieldChange)) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.onChange = callback
+}
 
-	values, err := v.loader.loadByPrefix(v.ctx, v.path)
+// refresh reloads all parameters from SSM Parameter Store, bypassing the cache, and returns
+// the values that differ from the previous snapshot. The snapshot is only replaced (and the
+// OnChange callback only called) when something changed.
+func (v *ViperRemoteProvider) refresh() ([]FieldChange, error) {
+	// A shared cache backend would otherwise answer the load with the values it last stored
+	v.loader.refreshCacheBackend(v.path)
+	values, err := v.loader.loadByPrefixWithCache(v.ctx, v.path, false)
 	if err != nil {
-		return fmt.Errorf("refreshing SSM parameters: %w", err)
+		return nil, fmt.Errorf("refreshing SSM parameters: %w", err)
 	}
 
 	v.mu.Lock()
-	v.values = values
+	changes := diffViperValues(v.values, values)
+	if len(changes) > 0 {
+		v.values = values
+	}
+	onChange := v.onChange
 	v.mu.Unlock()
 
-	return nil
+	if len(changes) > 0 && onChange != nil {
+		onChange(changes)
+	}
+	return changes, nil
+}
+
+// diffViperValues compares two parameter snapshots and returns the changed, added, and
+// removed values keyed by their Viper key, sorted by key.
+func diffViperValues(oldValues, newValues map[string]string) []FieldChange {
+	var changes []FieldChange
+	for key, newValue := range newValues {
+		oldValue, ok := oldValues[key]
+		if !ok {
+			oldValue = absentValue
+		}
+		if !ok || oldValue != newValue {
+			changes = append(changes, FieldChange{Path: viperKey(key), OldValue: oldValue, NewValue: newValue})
+		}
+	}
+	for key, oldValue := range oldValues {
+		if _, ok := newValues[key]; !ok {
+			changes = append(changes, FieldChange{Path: viperKey(key), OldValue: oldValue, NewValue: absentValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+// viperKey converts an SSM key (slash notation) to Viper's dot notation.
+// Example: "database/url" -> "database.url"
+func viperKey(key string) string {
+	return strings.ReplaceAll(key, "/", ".")
 }
 
 // convertKeyToSSMPath converts a Viper key (dot notation) to SSM path format.
@@ -144,7 +193,7 @@ func NewViperRemoteProvider(
 	}
 
 	// Initial load
-	if err := provider.refresh(); err != nil {
+	if _, err := provider.refresh(); err != nil {
 		cancel()
 		return nil, fmt.Errorf("initial SSM parameter load: %w", err)
 	}
@@ -189,9 +238,7 @@ func ReadRemoteConfig(ctx context.Context, prefix string, opts ...LoaderOption)
 	// Convert flat map to nested map structure for Viper
 	result := make(map[string]interface{})
 	for key, value := range values {
-		// Convert SSM path format to Viper dot notation
-		viperKey := strings.ReplaceAll(key, "/", ".")
-		result[viperKey] = value
+		result[viperKey(key)] = value
 	}
 
 	return result, nil
@@ -213,9 +260,7 @@ func (v *ViperRemoteProvider) GetViperValues() map[string]interface{} {
 
 	result := make(map[string]interface{})
 	for key, value := range v.values {
-		// Convert SSM path format to Viper dot notation
-		viperKey := strings.ReplaceAll(key, "/", ".")
-		result[viperKey] = value
+		result[viperKey(key)] = value
 	}
 
 	return result
@@ -0,0 +1,92 @@
+package ssmconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestViperRemoteProvider_Refresh(t *testing.T) {
+	client := newMockSSMClient(map[string]string{
+		"/myapp/database/url":  "postgres://old",
+		"/myapp/database/pool": "10",
+		"/myapp/debug":         "false",
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	provider := &ViperRemoteProvider{
+		providerName: "awsssm",
+		path:         "/myapp/",
+		loader:       newLoader(client),
+		values:       make(map[string]string),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+
+	var notified [][]FieldChange
+	provider.OnChange(func(changes []FieldChange) {
+		notified = append(notified, changes)
+	})
+
+	changes, err := provider.refresh()
+	require.NoError(t, err)
+	assert.Len(t, changes, 3)
+	require.Len(t, notified, 1)
+
+	t.Run("unchanged values emit nothing", func(t *testing.T) {
+		notified = nil
+		changes, err := provider.refresh()
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.Empty(t, notified)
+	})
+
+	t.Run("emits only changed, added, and removed values", func(t *testing.T) {
+		notified = nil
+		client.mu.Lock()
+		client.parameters["/myapp/database/url"] = "postgres://new"
+		client.parameters["/myapp/cache/ttl"] = "30s"
+		delete(client.parameters, "/myapp/debug")
+		client.mu.Unlock()
+
+		changes, err := provider.refresh()
+		require.NoError(t, err)
+		expected := []FieldChange{
+			{Path: "cache.ttl", OldValue: absentValue, NewValue: "30s"},
+			{Path: "database.url", OldValue: "postgres://old", NewValue: "postgres://new"},
+			{Path: "debug", OldValue: "false", NewValue: absentValue},
+		}
+		assert.Equal(t, expected, changes)
+		assert.Equal(t, [][]FieldChange{expected}, notified)
+
+		url, err := provider.Get("database.url")
+		require.NoError(t, err)
+		assert.Equal(t, "postgres://new", url)
+		_, err = provider.Get("debug")
+		assert.Error(t, err)
+	})
+}
+
+func TestViperRemoteProvider_RefreshWithCacheBackend(t *testing.T) {
+	client := newMockSSMClient(map[string]string{"/app/host": "old"})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	provider := &ViperRemoteProvider{
+		providerName: "awsssm",
+		path:         "/app/",
+		loader:       newLoader(client, WithCacheBackend(NewMemoryCache())),
+		values:       make(map[string]string),
+		ctx:          ctx,
+		cancel:       cancel,
+	}
+
+	_, err := provider.refresh()
+	require.NoError(t, err)
+
+	client.setParameter("/app/host", "new")
+	changes, err := provider.refresh()
+	require.NoError(t, err)
+	assert.Equal(t, []FieldChange{{Path: "host", OldValue: "old", NewValue: "new"}}, changes)
+}
@@ -0,0 +1,64 @@
+package ssmconfig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// whenCheck is a field validation deferred by a when tag until all fields of
+// its struct are set.
+type whenCheck struct {
+	fv          reflect.Value
+	validateTag string
+	whenTag     string
+	fieldName   string
+	fieldPath   string
+}
+
+// runConditional runs the validations deferred by when tags against the populated struct v.
+// A when tag names a sibling field by its Go name: when:"TLSEnabled=true" runs the field's
+// validators only if the sibling's value is "true"; when:"CertPath" only if the sibling isn't
+// its zero value.
+func (m *mapper) runConditional(v reflect.Value, pending []whenCheck) error {
+	for _, c := range pending {
+		ok, err := whenHolds(v, c.whenTag, c.fieldName)
+		if err != nil {
+			if ferr := m.fieldError(c.fieldPath, err); ferr != nil {
+				return ferr
+			}
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if err := m.validators.validateField(c.fv, c.validateTag, c.fieldName, m.opts.fsys); err != nil {
+			if ferr := m.fieldError(c.fieldPath, err); ferr != nil {
+				return ferr
+			}
+		}
+	}
+	return nil
+}
+
+// whenHolds evaluates a when tag against the sibling fields in v. Values are compared in
+// their fmt.Sprint form, with pointers dereferenced (a nil pointer matches nothing).
+func whenHolds(v reflect.Value, whenTag, fieldName string) (bool, error) {
+	name, want, hasWant := strings.Cut(whenTag, "=")
+	name = strings.TrimSpace(name)
+
+	sibling := v.FieldByName(name)
+	if !sibling.IsValid() {
+		return false, fmt.Errorf("when tag of field %s names unknown field '%s'", fieldName, name)
+	}
+	if !hasWant {
+		return !sibling.IsZero(), nil
+	}
+	if sibling.Kind() == reflect.Ptr {
+		if sibling.IsNil() {
+			return false, nil
+		}
+		sibling = sibling.Elem()
+	}
+	return fmt.Sprint(sibling.Interface()) == strings.TrimSpace(want), nil
+}
@@ -0,0 +1,70 @@
+package ssmconfig
+
+import (
+	"context"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWhenTag(t *testing.T) {
+	type Config struct {
+		CertPath   string `ssm:"cert_path" validate:"fileexists" when:"TLSEnabled=true"`
+		TLSEnabled bool   `ssm:"tls_enabled"`
+		Proxy      string `ssm:"proxy"`
+		ProxyPort  int    `ssm:"proxy_port" validate:"min:1" when:"Proxy"`
+	}
+	fsys := fstest.MapFS{"certs/server.pem": {Data: []byte("cert")}}
+
+	load := func(parameters map[string]string) (*Config, error) {
+		loader := newLoader(newMockSSMClient(parameters), WithConfigFS(fsys))
+		return LoadWithLoader[Config](loader, context.Background(), "/myapp/")
+	}
+
+	t.Run("validates when the condition holds", func(t *testing.T) {
+		_, err := load(map[string]string{
+			"/myapp/cert_path":   "certs/missing.pem",
+			"/myapp/tls_enabled": "true",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed for field 'CertPath' using validator 'fileexists'")
+
+		cfg, err := load(map[string]string{
+			"/myapp/cert_path":   "certs/server.pem",
+			"/myapp/tls_enabled": "true",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "certs/server.pem", cfg.CertPath)
+	})
+
+	t.Run("skips validation when the condition doesn't hold", func(t *testing.T) {
+		cfg, err := load(map[string]string{
+			"/myapp/cert_path":   "certs/missing.pem",
+			"/myapp/tls_enabled": "false",
+			"/myapp/proxy_port":  "0",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "certs/missing.pem", cfg.CertPath)
+	})
+
+	t.Run("a bare field name requires a non-zero sibling", func(t *testing.T) {
+		_, err := load(map[string]string{
+			"/myapp/proxy":      "proxy.internal",
+			"/myapp/proxy_port": "0",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "validation failed for field 'ProxyPort'")
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		type BadConfig struct {
+			Port int `ssm:"port" validate:"min:1" when:"Missing=true"`
+		}
+		var result BadConfig
+		err := mapToStruct(map[string]string{"port": "80"}, &result, false, nil, true)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "when tag of field Port names unknown field 'Missing'")
+	})
+}